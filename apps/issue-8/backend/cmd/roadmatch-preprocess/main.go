@@ -0,0 +1,206 @@
+// Command roadmatch-preprocess converts an OSM XML extract of Taiwan's
+// freeway network into the flat GeoJSON format that roadmatch.LoadGeoJSON
+// consumes at server startup.
+//
+// Plain public OSM data doesn't carry freeway direction or mileage-marker
+// tags (those are MOTC/TDX-specific), so the input way elements are expected
+// to already be enriched with "direction" (N/S or north/south) and "mileage"
+// tags — e.g. by joining a public OSM extract against TDX's freeway mileage
+// dataset upstream of this tool. Ways missing either tag are skipped with a
+// warning rather than silently defaulting, since a wrong guess here would
+// corrupt the "ahead of the driver" ordering this data feeds.
+//
+// Only the .osm.xml form is supported; .osm.pbf (a binary protobuf format)
+// and MOTC shapefiles are out of scope for this tool today.
+//
+// This is a build-time step, not something the server runs itself: the
+// output is small enough to commit or ship alongside the binary, and
+// re-running it is only needed when the upstream road network data changes.
+//
+//	go run ./cmd/roadmatch-preprocess -in taiwan-freeways.osm.xml -out freeways.geojson
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// osmDoc is the subset of OSM XML this tool understands: nodes (id + lat/lon)
+// and ways tagged as freeway centerlines, with the tags we care about.
+type osmDoc struct {
+	Nodes []osmNode `xml:"node"`
+	Ways  []osmWay  `xml:"way"`
+}
+
+type osmNode struct {
+	ID  string  `xml:"id,attr"`
+	Lat float64 `xml:"lat,attr"`
+	Lon float64 `xml:"lon,attr"`
+}
+
+type osmWay struct {
+	Tags []osmTag   `xml:"tag"`
+	Nds  []osmNdRef `xml:"nd"`
+}
+
+type osmTag struct {
+	Key   string `xml:"k,attr"`
+	Value string `xml:"v,attr"`
+}
+
+type osmNdRef struct {
+	Ref string `xml:"ref,attr"`
+}
+
+func (w osmWay) tag(key string) string {
+	for _, t := range w.Tags {
+		if t.Key == key {
+			return t.Value
+		}
+	}
+	return ""
+}
+
+func main() {
+	inPath := flag.String("in", "", "path to an OSM XML extract of Taiwan's freeway network, with direction/mileage tags joined in")
+	outPath := flag.String("out", "freeways.geojson", "path to write the preprocessed GeoJSON to")
+	flag.Parse()
+
+	if *inPath == "" {
+		log.Fatal("roadmatch-preprocess: -in is required")
+	}
+
+	if err := run(*inPath, *outPath); err != nil {
+		log.Fatalf("roadmatch-preprocess: %v", err)
+	}
+}
+
+func run(inPath, outPath string) error {
+	if strings.HasSuffix(strings.ToLower(inPath), ".pbf") {
+		return fmt.Errorf("%s looks like OSM PBF, which this tool can't parse (only .osm.xml is supported)", inPath)
+	}
+
+	in, err := os.Open(inPath)
+	if err != nil {
+		return fmt.Errorf("open input: %w", err)
+	}
+	defer in.Close()
+
+	var doc osmDoc
+	if err := xml.NewDecoder(in).Decode(&doc); err != nil {
+		return fmt.Errorf("parse osm xml: %w", err)
+	}
+
+	nodesByID := make(map[string]osmNode, len(doc.Nodes))
+	for _, n := range doc.Nodes {
+		nodesByID[n.ID] = n
+	}
+
+	features := make([]geoJSONFeature, 0, len(doc.Ways))
+	skipped := 0
+	for _, way := range doc.Ways {
+		highway := way.tag("ref") // e.g. "國道1號"
+		if highway == "" {
+			continue
+		}
+
+		direction, ok := directionTagToLabel(way.tag("direction"))
+		if !ok {
+			log.Printf("roadmatch-preprocess: skipping %s way with missing/unrecognized direction tag", highway)
+			skipped++
+			continue
+		}
+		mileage, err := strconv.ParseFloat(way.tag("mileage"), 64)
+		if err != nil {
+			log.Printf("roadmatch-preprocess: skipping %s way with missing/invalid mileage tag", highway)
+			skipped++
+			continue
+		}
+
+		coords := make([][2]float64, 0, len(way.Nds))
+		for _, nd := range way.Nds {
+			node, ok := nodesByID[nd.Ref]
+			if !ok {
+				continue
+			}
+			coords = append(coords, [2]float64{node.Lon, node.Lat})
+		}
+		if len(coords) < 2 {
+			continue
+		}
+
+		features = append(features, geoJSONFeature{
+			Type: "Feature",
+			Properties: geoJSONProps{
+				Highway:   highway,
+				Direction: direction,
+				Mileage:   mileage,
+			},
+			Geometry: geoJSONGeometry{
+				Type:        "LineString",
+				Coordinates: coords,
+			},
+		})
+	}
+
+	out, err := os.Create(outPath)
+	if err != nil {
+		return fmt.Errorf("create output: %w", err)
+	}
+	defer out.Close()
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(geoJSONCollection{Type: "FeatureCollection", Features: features}); err != nil {
+		return fmt.Errorf("write geojson: %w", err)
+	}
+
+	log.Printf("roadmatch-preprocess: wrote %d segments to %s (skipped %d ways missing direction/mileage tags)", len(features), outPath, skipped)
+	return nil
+}
+
+// directionTagToLabel maps an OSM "direction" tag value to our 北上/南下
+// label. ok is false when the tag is missing or not one of the recognized
+// values, since guessing wrong here would silently corrupt ahead-of-driver
+// ordering downstream.
+func directionTagToLabel(direction string) (label string, ok bool) {
+	switch direction {
+	case "N", "north":
+		return "北上", true
+	case "S", "south":
+		return "南下", true
+	default:
+		return "", false
+	}
+}
+
+// The geoJSON* types mirror roadmatch's internal decoding types; duplicated
+// here rather than imported so this preprocessor can be vendored as a
+// standalone tool without pulling in the server's runtime dependencies.
+type geoJSONCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string          `json:"type"`
+	Properties geoJSONProps    `json:"properties"`
+	Geometry   geoJSONGeometry `json:"geometry"`
+}
+
+type geoJSONProps struct {
+	Highway   string  `json:"highway"`
+	Direction string  `json:"direction"`
+	Mileage   float64 `json:"mileage"`
+}
+
+type geoJSONGeometry struct {
+	Type        string       `json:"type"`
+	Coordinates [][2]float64 `json:"coordinates"`
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirectionTagToLabel(t *testing.T) {
+	tests := []struct {
+		direction string
+		wantLabel string
+		wantOK    bool
+	}{
+		{"N", "北上", true},
+		{"north", "北上", true},
+		{"S", "南下", true},
+		{"south", "南下", true},
+		{"", "", false},
+		{"NE", "", false},
+	}
+
+	for _, tt := range tests {
+		label, ok := directionTagToLabel(tt.direction)
+		if label != tt.wantLabel || ok != tt.wantOK {
+			t.Errorf("directionTagToLabel(%q) = (%q, %v), want (%q, %v)", tt.direction, label, ok, tt.wantLabel, tt.wantOK)
+		}
+	}
+}
+
+// Ways are deliberately unordered and deliberately include one with a
+// missing direction tag and one with an invalid mileage tag, so run()'s
+// skip-with-count behavior is exercised rather than its happy path alone.
+const testOSMXML = `<?xml version="1.0"?>
+<osm>
+  <node id="1" lat="24.0" lon="121.0"/>
+  <node id="2" lat="24.1" lon="121.0"/>
+  <node id="3" lat="23.8" lon="120.5"/>
+  <node id="4" lat="23.9" lon="120.5"/>
+  <way>
+    <tag k="ref" v="國道1號"/>
+    <tag k="direction" v="N"/>
+    <tag k="mileage" v="97.0"/>
+    <nd ref="1"/>
+    <nd ref="2"/>
+  </way>
+  <way>
+    <tag k="ref" v="國道1號"/>
+    <tag k="mileage" v="100.0"/>
+    <nd ref="3"/>
+    <nd ref="4"/>
+  </way>
+  <way>
+    <tag k="ref" v="國道1號"/>
+    <tag k="direction" v="S"/>
+    <tag k="mileage" v="not-a-number"/>
+    <nd ref="3"/>
+    <nd ref="4"/>
+  </way>
+</osm>`
+
+func TestRun_SkipsWaysMissingDirectionOrMileage(t *testing.T) {
+	dir := t.TempDir()
+	inPath := filepath.Join(dir, "in.osm.xml")
+	outPath := filepath.Join(dir, "out.geojson")
+
+	if err := os.WriteFile(inPath, []byte(testOSMXML), 0o644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+
+	if err := run(inPath, outPath); err != nil {
+		t.Fatalf("run() = %v, want nil", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	var got geoJSONCollection
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+
+	if len(got.Features) != 1 {
+		t.Fatalf("got %d features, want 1 (the other two ways are missing/invalid direction or mileage tags)", len(got.Features))
+	}
+	f := got.Features[0]
+	if f.Properties.Direction != "北上" || f.Properties.Mileage != 97.0 {
+		t.Errorf("got properties %+v, want direction=北上 mileage=97.0", f.Properties)
+	}
+}
+
+func TestRun_RejectsPBF(t *testing.T) {
+	if err := run("taiwan-freeways.osm.pbf", filepath.Join(t.TempDir(), "out.geojson")); err == nil {
+		t.Fatalf("run() with a .pbf input = nil error, want an error since PBF isn't supported")
+	}
+}
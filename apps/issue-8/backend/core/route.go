@@ -0,0 +1,149 @@
+package core
+
+import (
+	"context"
+	"sort"
+
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/roadmatch"
+)
+
+// defaultAlongRouteLimit and defaultMaxDistanceKm are used when an
+// AlongRouteRequest doesn't specify them.
+const (
+	defaultAlongRouteLimit = 5
+	defaultMaxDistanceKm   = 100.0
+)
+
+// aheadArea pairs a service area with its distance along the route, so the
+// areas can be sorted by how soon the driver reaches them.
+type aheadArea struct {
+	area          ServiceArea
+	routeDistance float64
+}
+
+// FindAlongRoute returns the ordered list of upcoming service areas the
+// driver will pass in their direction of travel, nearest first, each
+// annotated with distance, ETA, and parking status.
+func (s *Service) FindAlongRoute(ctx context.Context, req AlongRouteRequest) ([]ServiceArea, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultAlongRouteLimit
+	}
+	maxDistanceKm := req.MaxDistanceKm
+	if maxDistanceKm <= 0 {
+		maxDistanceKm = defaultMaxDistanceKm
+	}
+
+	var match roadmatch.Match
+	if s.roadIndex != nil {
+		match = s.roadIndex.SnapToHighway(req.Latitude, req.Longitude, req.Heading)
+		if !match.OnFreeway {
+			return nil, ErrNotOnFreeway
+		}
+	} else if req.Latitude < 20 || req.Latitude > 26 || req.Longitude < 118 || req.Longitude > 123 {
+		return nil, ErrNotOnFreeway
+	}
+
+	destMileage, hasDestMileage := s.destinationMileage(req.Destination)
+
+	candidates := s.aheadCandidates(req.LocationRequest, s.store.snapshot(), match, destMileage, hasDestMileage)
+
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].routeDistance < candidates[j].routeDistance })
+
+	result := make([]ServiceArea, 0, limit)
+	for _, c := range candidates {
+		if c.routeDistance > maxDistanceKm {
+			break
+		}
+
+		area := c.area
+		area.Distance = haversineDistance(req.Latitude, req.Longitude, area.Latitude, area.Longitude)
+		area.ETA = s.estimateETA(ctx, req.LocationRequest, &area, area.Distance)
+		if area.ParkingInfo == nil {
+			area.ParkingInfo = mockParkingInfo()
+		}
+
+		result = append(result, area)
+		if len(result) == limit {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// aheadCandidates filters and ranks service areas ahead of the driver.
+//
+// With a road match, "ahead" is derived from freeway mileage markers. TDX
+// mileage markers increase southward (km 0 at Keelung), so driving 南下
+// (southbound) areas with a higher mileage are ahead, and driving 北上
+// (northbound) areas with a lower mileage are ahead. Without a road match
+// there is no mileage anchor, so this falls back to ranking same-direction
+// areas by straight-line distance.
+func (s *Service) aheadCandidates(req LocationRequest, areas []ServiceArea, match roadmatch.Match, destMileage float64, hasDestMileage bool) []aheadArea {
+	direction := "北上"
+	if req.Heading > 90 && req.Heading < 270 {
+		direction = "南下"
+	}
+	if match.OnFreeway {
+		direction = match.Direction
+	}
+
+	var out []aheadArea
+	for _, area := range areas {
+		if area.Direction != direction {
+			continue
+		}
+		if match.OnFreeway && area.Highway != match.Highway {
+			continue
+		}
+
+		if !match.OnFreeway {
+			out = append(out, aheadArea{
+				area:          area,
+				routeDistance: haversineDistance(req.Latitude, req.Longitude, area.Latitude, area.Longitude),
+			})
+			continue
+		}
+
+		var mileageDelta float64
+		if direction == "南下" {
+			mileageDelta = area.Mileage - match.Mileage
+		} else {
+			mileageDelta = match.Mileage - area.Mileage
+		}
+		if mileageDelta < 0 {
+			continue // behind the driver
+		}
+		if hasDestMileage {
+			aheadOfDest := direction == "南下" && area.Mileage > destMileage
+			aheadOfDest = aheadOfDest || (direction == "北上" && area.Mileage < destMileage)
+			if aheadOfDest {
+				continue // past where the driver is headed
+			}
+		}
+
+		out = append(out, aheadArea{area: area, routeDistance: mileageDelta})
+	}
+
+	return out
+}
+
+// destinationMileage resolves an optional Destination to a mileage marker
+// via the road-match index, when coordinates are given. Highway-exit-name
+// destinations aren't resolvable without an exit gazetteer, so they're
+// accepted but not yet used to bound the search.
+func (s *Service) destinationMileage(dest *Destination) (mileage float64, ok bool) {
+	if dest == nil || s.roadIndex == nil {
+		return 0, false
+	}
+	if dest.Latitude == 0 && dest.Longitude == 0 {
+		return 0, false
+	}
+
+	match := s.roadIndex.SnapToHighway(dest.Latitude, dest.Longitude, 0)
+	if !match.OnFreeway {
+		return 0, false
+	}
+	return match.Mileage, true
+}
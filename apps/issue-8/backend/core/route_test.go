@@ -0,0 +1,74 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/roadmatch"
+)
+
+// mileageTestAreas mirrors the shape of mockServiceAreas: one southbound and
+// one northbound area behind the match point, and one of each ahead.
+func mileageTestAreas() []ServiceArea {
+	return []ServiceArea{
+		{ID: "behind-north", Direction: "北上", Highway: "國道1號", Mileage: 50},
+		{ID: "ahead-north", Direction: "北上", Highway: "國道1號", Mileage: 10},
+		{ID: "behind-south", Direction: "南下", Highway: "國道1號", Mileage: 10},
+		{ID: "ahead-south", Direction: "南下", Highway: "國道1號", Mileage: 50},
+	}
+}
+
+// TestAheadCandidates_MileageConvention locks in TDX's mileage convention:
+// markers increase southward (km 0 at Keelung), so driving 南下 ahead means
+// higher mileage, and driving 北上 ahead means lower mileage.
+func TestAheadCandidates_MileageConvention(t *testing.T) {
+	s := &Service{}
+	areas := mileageTestAreas()
+
+	t.Run("南下 ahead is higher mileage", func(t *testing.T) {
+		match := roadmatch.Match{OnFreeway: true, Highway: "國道1號", Direction: "南下", Mileage: 30}
+		out := s.aheadCandidates(LocationRequest{}, areas, match, 0, false)
+
+		if len(out) != 1 || out[0].area.ID != "ahead-south" {
+			t.Fatalf("got %+v, want exactly [ahead-south]", out)
+		}
+	})
+
+	t.Run("北上 ahead is lower mileage", func(t *testing.T) {
+		match := roadmatch.Match{OnFreeway: true, Highway: "國道1號", Direction: "北上", Mileage: 30}
+		out := s.aheadCandidates(LocationRequest{}, areas, match, 0, false)
+
+		if len(out) != 1 || out[0].area.ID != "ahead-north" {
+			t.Fatalf("got %+v, want exactly [ahead-north]", out)
+		}
+	})
+}
+
+func TestAheadCandidates_StopsAtDestinationMileage(t *testing.T) {
+	s := &Service{}
+	areas := []ServiceArea{
+		{ID: "before-dest", Direction: "南下", Highway: "國道1號", Mileage: 40},
+		{ID: "past-dest", Direction: "南下", Highway: "國道1號", Mileage: 80},
+	}
+	match := roadmatch.Match{OnFreeway: true, Highway: "國道1號", Direction: "南下", Mileage: 30}
+
+	out := s.aheadCandidates(LocationRequest{}, areas, match, 50, true)
+
+	if len(out) != 1 || out[0].area.ID != "before-dest" {
+		t.Fatalf("got %+v, want exactly [before-dest] when destination mileage is 50", out)
+	}
+}
+
+func TestAheadCandidates_FiltersByHighwayAndDirection(t *testing.T) {
+	s := &Service{}
+	areas := []ServiceArea{
+		{ID: "other-highway", Direction: "南下", Highway: "國道3號", Mileage: 40},
+		{ID: "same-highway", Direction: "南下", Highway: "國道1號", Mileage: 40},
+	}
+	match := roadmatch.Match{OnFreeway: true, Highway: "國道1號", Direction: "南下", Mileage: 30}
+
+	out := s.aheadCandidates(LocationRequest{}, areas, match, 0, false)
+
+	if len(out) != 1 || out[0].area.ID != "same-highway" {
+		t.Fatalf("got %+v, want only same-highway areas", out)
+	}
+}
@@ -0,0 +1,254 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/eta"
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/geocode"
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/roadmatch"
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/tdx"
+)
+
+// ErrNotOnFreeway is returned by FindNearest when the roadmatch index
+// rejects the request's coordinates as not being on a freeway.
+var ErrNotOnFreeway = errors.New("core: location is not on a freeway")
+
+// ErrNoServiceAreaFound is returned by FindNearest when no service area
+// matches the request's direction (and highway, if road-matched).
+var ErrNoServiceAreaFound = errors.New("core: no service area found")
+
+// ErrAddressNotFound is returned by FindNearest when req.Address couldn't be
+// resolved to a coordinate via forward geocoding.
+var ErrAddressNotFound = errors.New("core: address not found")
+
+// Service implements the nearest-service-area lookup shared by the HTTP and
+// gRPC API surfaces.
+type Service struct {
+	store         *serviceAreaStore
+	etaRouter     *eta.Router
+	roadIndex     *roadmatch.Index
+	geocodeClient *geocode.Client
+}
+
+// NewService builds a Service. tdxClient, roadIndex, and geocodeClient may
+// all be nil, in which case the service serves mock data, falls back to the
+// coarse bounding-box check, and skips NearbyContext enrichment and
+// address-based lookups, respectively.
+func NewService(tdxClient *tdx.Client, etaRouter *eta.Router, roadIndex *roadmatch.Index, geocodeClient *geocode.Client) *Service {
+	return &Service{
+		store:         newServiceAreaStore(tdxClient),
+		etaRouter:     etaRouter,
+		roadIndex:     roadIndex,
+		geocodeClient: geocodeClient,
+	}
+}
+
+// StartBackgroundRefresh starts the TDX polling goroutine. Call it once with
+// a long-lived context.
+func (s *Service) StartBackgroundRefresh(ctx context.Context, interval time.Duration) {
+	s.store.startBackgroundRefresh(ctx, interval)
+}
+
+// SyncStatus reports TDX sync health for the /api/health endpoint.
+func (s *Service) SyncStatus() (usingMock bool, lastSyncedAt time.Time, lastError string) {
+	return s.store.status()
+}
+
+// ListServiceAreas returns a snapshot of all known service areas, optionally
+// filtered to a single highway (an empty filter returns everything).
+func (s *Service) ListServiceAreas(highwayFilter string) []ServiceArea {
+	areas := s.store.snapshot()
+	if highwayFilter == "" {
+		return areas
+	}
+
+	filtered := areas[:0]
+	for _, area := range areas {
+		if area.Highway == highwayFilter {
+			filtered = append(filtered, area)
+		}
+	}
+	return filtered
+}
+
+// FindNearest locates the nearest service area ahead of the driver given
+// their current location, heading, and speed. When a road-match index is
+// configured, it also validates that the driver is actually on a freeway
+// and restricts candidates to that freeway.
+func (s *Service) FindNearest(ctx context.Context, req LocationRequest) (*ServiceArea, error) {
+	if req.Address != "" && req.Latitude == 0 && req.Longitude == 0 {
+		if err := s.resolveAddress(ctx, &req); err != nil {
+			return nil, err
+		}
+	}
+
+	var match roadmatch.Match
+	if s.roadIndex != nil {
+		match = s.roadIndex.SnapToHighway(req.Latitude, req.Longitude, req.Heading)
+		if !match.OnFreeway {
+			return nil, ErrNotOnFreeway
+		}
+	} else if req.Latitude < 20 || req.Latitude > 26 || req.Longitude < 118 || req.Longitude > 123 {
+		// Coarse fallback when no road network index is loaded.
+		return nil, ErrNotOnFreeway
+	}
+
+	nearest := s.findNearest(ctx, req, s.store.snapshot(), match)
+	if nearest == nil {
+		return nil, ErrNoServiceAreaFound
+	}
+
+	// Service areas served from the mock fallback carry no TDX parking data.
+	if nearest.ParkingInfo == nil {
+		nearest.ParkingInfo = mockParkingInfo()
+	}
+
+	s.enrichNearbyContext(ctx, nearest)
+
+	return nearest, nil
+}
+
+// resolveAddress forward-geocodes req.Address into req.Latitude/Longitude.
+func (s *Service) resolveAddress(ctx context.Context, req *LocationRequest) error {
+	if s.geocodeClient == nil {
+		return ErrAddressNotFound
+	}
+
+	coord, err := s.geocodeClient.Forward(ctx, req.Address)
+	if err != nil {
+		return ErrAddressNotFound
+	}
+
+	req.Latitude = coord.Lat
+	req.Longitude = coord.Lon
+	return nil
+}
+
+// enrichNearbyContext adds reverse-geocoded township/district/exit context
+// to a service area. Service area locations are static, so the underlying
+// geocode.Client caches this indefinitely.
+func (s *Service) enrichNearbyContext(ctx context.Context, area *ServiceArea) {
+	if s.geocodeClient == nil {
+		return
+	}
+
+	addr, err := s.geocodeClient.Reverse(ctx, area.Latitude, area.Longitude)
+	if err != nil {
+		return
+	}
+
+	area.NearbyContext = &NearbyContext{
+		Township: addr.Township,
+		District: addr.District,
+	}
+}
+
+func (s *Service) findNearest(ctx context.Context, req LocationRequest, serviceAreas []ServiceArea, match roadmatch.Match) *ServiceArea {
+	var nearest *ServiceArea
+	minDistance := 999999.0
+
+	// Prefer the direction derived from the matched road segment's tangent;
+	// fall back to the coarse heading heuristic when no road match is
+	// available.
+	// 0-90 or 270-360 = 北上, 90-270 = 南下
+	direction := "北上"
+	if req.Heading > 90 && req.Heading < 270 {
+		direction = "南下"
+	}
+	if match.OnFreeway {
+		direction = match.Direction
+	}
+
+	for i := range serviceAreas {
+		area := &serviceAreas[i]
+
+		// Filter by direction
+		if area.Direction != direction {
+			continue
+		}
+
+		// When on-freeway, restrict candidates to the same highway.
+		if match.OnFreeway && area.Highway != match.Highway {
+			continue
+		}
+
+		dist := haversineDistance(req.Latitude, req.Longitude, area.Latitude, area.Longitude)
+		if dist < minDistance {
+			minDistance = dist
+			nearest = area
+		}
+	}
+
+	if nearest != nil {
+		nearest.Distance = minDistance
+		nearest.ETA = s.estimateETA(ctx, req, nearest, minDistance)
+	}
+
+	return nearest
+}
+
+// estimateETA asks the ETA router for a duration between the user's
+// location and the service area, falling back to the simple
+// distance/speed estimate if the router is unavailable.
+func (s *Service) estimateETA(ctx context.Context, req LocationRequest, area *ServiceArea, distanceKm float64) string {
+	if s.etaRouter != nil {
+		from := eta.Coordinate{Lat: req.Latitude, Lon: req.Longitude}
+		to := eta.Coordinate{Lat: area.Latitude, Lon: area.Longitude}
+		if duration, _, err := s.etaRouter.DurationDistance(ctx, from, to); err == nil {
+			return fmt.Sprintf("%.0f 分鐘", duration.Minutes())
+		}
+	}
+
+	if req.Speed > 0 {
+		etaMinutes := (distanceKm / req.Speed) * 60
+		return fmt.Sprintf("%.0f 分鐘", etaMinutes)
+	}
+	return "計算中..."
+}
+
+// haversineDistance calculates the distance between two lat/lng points in kilometers
+func haversineDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	const R = 6371.0 // Earth radius in kilometers
+
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	deltaLat := (lat2 - lat1) * math.Pi / 180
+	deltaLon := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1Rad)*math.Cos(lat2Rad)*
+			math.Sin(deltaLon/2)*math.Sin(deltaLon/2)
+
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return R * c
+}
+
+func mockParkingInfo() *ParkingInfo {
+	// Mock data - served when TDX has no parking record for the area yet.
+	available := 128
+	total := 200
+	ratio := float64(available) / float64(total)
+
+	status := "已滿"
+	colorCode := "#dc3545" // red
+
+	if ratio > 0.5 {
+		status = "充足"
+		colorCode = "#28a745" // green
+	} else if ratio >= 0.2 {
+		status = "稍滿"
+		colorCode = "#ffc107" // yellow
+	}
+
+	return &ParkingInfo{
+		Status:          status,
+		AvailableSpaces: available,
+		TotalSpaces:     total,
+		ColorCode:       colorCode,
+	}
+}
@@ -0,0 +1,203 @@
+package core
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/tdx"
+)
+
+// defaultPollInterval is how often the store refreshes data from TDX when
+// no interval is configured.
+const defaultPollInterval = 5 * time.Minute
+
+// mockServiceAreas is the fallback data served whenever TDX credentials are
+// missing or the last sync attempt failed.
+var mockServiceAreas = []ServiceArea{
+	{
+		ID:        "1",
+		Name:      "湖口服務區",
+		Direction: "北上",
+		Highway:   "國道1號",
+		Latitude:  24.9051,
+		Longitude: 121.0398,
+		Mileage:   97.0,
+	},
+	{
+		ID:        "2",
+		Name:      "湖口服務區",
+		Direction: "南下",
+		Highway:   "國道1號",
+		Latitude:  24.9051,
+		Longitude: 121.0398,
+		Mileage:   97.0,
+	},
+	{
+		ID:        "3",
+		Name:      "西螺服務區",
+		Direction: "北上",
+		Highway:   "國道1號",
+		Latitude:  23.7951,
+		Longitude: 120.4698,
+		Mileage:   233.0,
+	},
+}
+
+// serviceAreaStore holds the merged service area + parking data and tracks
+// whether it is currently serving live TDX data or the mock fallback.
+type serviceAreaStore struct {
+	client *tdx.Client
+
+	mu           sync.RWMutex
+	areas        []ServiceArea
+	usingMock    bool
+	lastSyncedAt time.Time
+	lastError    string
+}
+
+// newServiceAreaStore creates a store seeded with the mock data. If client is
+// nil the store never attempts to reach TDX and always serves mock data.
+func newServiceAreaStore(client *tdx.Client) *serviceAreaStore {
+	return &serviceAreaStore{
+		client:    client,
+		areas:     append([]ServiceArea(nil), mockServiceAreas...),
+		usingMock: true,
+	}
+}
+
+// snapshot returns a copy of the currently cached service areas.
+func (s *serviceAreaStore) snapshot() []ServiceArea {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]ServiceArea, len(s.areas))
+	copy(out, s.areas)
+	return out
+}
+
+// status reports sync health for the /api/health endpoint.
+func (s *serviceAreaStore) status() (usingMock bool, lastSyncedAt time.Time, lastError string) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.usingMock, s.lastSyncedAt, s.lastError
+}
+
+// startBackgroundRefresh polls TDX on the given interval until ctx is
+// cancelled, merging results into the store and falling back to mock data
+// whenever TDX is unreachable.
+func (s *serviceAreaStore) startBackgroundRefresh(ctx context.Context, interval time.Duration) {
+	if s.client == nil {
+		log.Println("tdx: no credentials configured, serving mock data only")
+		return
+	}
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	s.refresh(ctx)
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.refresh(ctx)
+			}
+		}
+	}()
+}
+
+// refresh performs a single TDX sync, merging service area metadata with
+// parking availability. On any failure it leaves the previous cache (or the
+// mock data) in place and records the error for /api/health.
+func (s *serviceAreaStore) refresh(ctx context.Context) {
+	areaInfos, err := s.client.FetchServiceAreas(ctx)
+	if err != nil {
+		s.recordFailure(err)
+		return
+	}
+
+	parking, err := s.client.FetchParkingAvailability(ctx)
+	if err != nil {
+		s.recordFailure(err)
+		return
+	}
+
+	parkingByID := make(map[string]tdx.ParkingAvailability, len(parking))
+	for _, p := range parking {
+		parkingByID[p.ServiceAreaID] = p
+	}
+
+	merged := make([]ServiceArea, 0, len(areaInfos))
+	for _, info := range areaInfos {
+		area := ServiceArea{
+			ID:        info.ID,
+			Name:      info.Name,
+			Direction: tdxDirectionToLabel(info.Direction),
+			Highway:   info.Highway,
+			Latitude:  info.PositionLat,
+			Longitude: info.PositionLon,
+			Mileage:   info.Mileage,
+		}
+		if p, ok := parkingByID[info.ID]; ok {
+			area.ParkingInfo = parkingInfoFromTDX(p)
+		}
+		merged = append(merged, area)
+	}
+
+	s.mu.Lock()
+	s.areas = merged
+	s.usingMock = false
+	s.lastSyncedAt = time.Now()
+	s.lastError = ""
+	s.mu.Unlock()
+}
+
+func (s *serviceAreaStore) recordFailure(err error) {
+	s.mu.Lock()
+	s.lastError = err.Error()
+	if s.usingMock {
+		log.Printf("tdx: sync failed, still serving mock data: %v", err)
+	} else {
+		log.Printf("tdx: sync failed, keeping last synced data: %v", err)
+	}
+	s.mu.Unlock()
+}
+
+// tdxDirectionToLabel converts TDX's N/S direction codes into the labels the
+// rest of the API uses.
+func tdxDirectionToLabel(direction string) string {
+	if direction == "S" {
+		return "南下"
+	}
+	return "北上"
+}
+
+// parkingInfoFromTDX converts a TDX parking availability record into the
+// API's ParkingInfo shape, including the traffic-light colour coding.
+func parkingInfoFromTDX(p tdx.ParkingAvailability) *ParkingInfo {
+	status := "已滿"
+	colorCode := "#dc3545"
+
+	if p.TotalSpaces > 0 {
+		ratio := float64(p.AvailableSpaces) / float64(p.TotalSpaces)
+		if ratio > 0.5 {
+			status = "充足"
+			colorCode = "#28a745"
+		} else if ratio >= 0.2 {
+			status = "稍滿"
+			colorCode = "#ffc107"
+		}
+	}
+
+	return &ParkingInfo{
+		Status:          status,
+		AvailableSpaces: p.AvailableSpaces,
+		TotalSpaces:     p.TotalSpaces,
+		ColorCode:       colorCode,
+	}
+}
@@ -0,0 +1,47 @@
+package core
+
+import "testing"
+
+// TestRecordFailure_KeepsUsingRealDataAfterSuccessfulSync locks in that a
+// sync failure following a successful sync does not flip usingMock back to
+// true — /api/health's tdxSource must keep reporting "tdx" since s.areas
+// still holds the last real data.
+func TestRecordFailure_KeepsUsingRealDataAfterSuccessfulSync(t *testing.T) {
+	s := &serviceAreaStore{
+		areas:     []ServiceArea{{ID: "1", Name: "湖口服務區"}},
+		usingMock: false,
+	}
+
+	s.recordFailure(errTest)
+
+	usingMock, _, lastError := s.status()
+	if usingMock {
+		t.Errorf("usingMock = true, want false: a failure after a successful sync should keep serving the last real data")
+	}
+	if lastError != errTest.Error() {
+		t.Errorf("lastError = %q, want %q", lastError, errTest.Error())
+	}
+}
+
+// TestRecordFailure_StaysMockWithoutPriorSync locks in that a failure before
+// any successful sync leaves the store serving the constructor-seeded mock
+// data, so /api/health's tdxSource correctly stays "mock".
+func TestRecordFailure_StaysMockWithoutPriorSync(t *testing.T) {
+	s := newServiceAreaStore(nil)
+
+	s.recordFailure(errTest)
+
+	usingMock, _, lastError := s.status()
+	if !usingMock {
+		t.Errorf("usingMock = false, want true: a failure with no prior successful sync must keep serving mock data")
+	}
+	if lastError != errTest.Error() {
+		t.Errorf("lastError = %q, want %q", lastError, errTest.Error())
+	}
+}
+
+var errTest = testError("simulated TDX fetch error")
+
+type testError string
+
+func (e testError) Error() string { return string(e) }
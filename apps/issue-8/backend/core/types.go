@@ -0,0 +1,64 @@
+// Package core holds the service-area lookup logic shared by the HTTP and
+// gRPC API surfaces, so both transports stay behind one implementation of
+// "find the nearest service area" instead of drifting apart.
+package core
+
+// ServiceArea represents a highway service area.
+type ServiceArea struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Direction   string       `json:"direction"` // 北上 or 南下
+	Highway     string       `json:"highway"`   // 國道1號, 國道3號, etc.
+	Latitude    float64      `json:"latitude"`
+	Longitude   float64      `json:"longitude"`
+	Mileage     float64      `json:"mileage"` // 公里數
+	Distance    float64      `json:"distance,omitempty"`
+	ETA         string       `json:"eta,omitempty"`
+	ParkingInfo *ParkingInfo `json:"parkingInfo,omitempty"`
+
+	// NearbyContext is derived from reverse geocoding the area's static
+	// coordinates: township/district.
+	NearbyContext *NearbyContext `json:"nearbyContext,omitempty"`
+}
+
+// NearbyContext is the human-readable landmark context around a service
+// area, derived from reverse geocoding.
+type NearbyContext struct {
+	Township string `json:"township,omitempty"`
+	District string `json:"district,omitempty"`
+}
+
+// ParkingInfo represents parking availability status.
+type ParkingInfo struct {
+	Status          string `json:"status"` // 充足, 稍滿, 已滿
+	AvailableSpaces int    `json:"availableSpaces"`
+	TotalSpaces     int    `json:"totalSpaces"`
+	ColorCode       string `json:"colorCode"` // #28a745 (green), #ffc107 (yellow), #dc3545 (red)
+}
+
+// LocationRequest represents the user's location and movement data. Address
+// may be given instead of Latitude/Longitude, in which case it is resolved
+// via forward geocoding before the lookup runs.
+type LocationRequest struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Heading   float64 `json:"heading"` // 0-360 degrees, 0 = North
+	Speed     float64 `json:"speed"`   // km/h
+	Address   string  `json:"address,omitempty"`
+}
+
+// Destination optionally narrows an along-route query to service areas
+// before a given point: either a lat/lng or a named highway exit.
+type Destination struct {
+	Latitude    float64 `json:"latitude,omitempty"`
+	Longitude   float64 `json:"longitude,omitempty"`
+	HighwayExit string  `json:"highwayExit,omitempty"`
+}
+
+// AlongRouteRequest describes a "next N service areas along my trip" query.
+type AlongRouteRequest struct {
+	LocationRequest
+	Destination   *Destination `json:"destination,omitempty"`
+	Limit         int          `json:"limit,omitempty"`
+	MaxDistanceKm float64      `json:"maxDistanceKm,omitempty"`
+}
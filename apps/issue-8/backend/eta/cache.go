@@ -0,0 +1,108 @@
+package eta
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultGridPrecision is the number of decimal places coordinates are
+// rounded to before being used as a cache key, roughly 11m of precision at
+// Taiwan's latitude.
+const defaultGridPrecision = 4
+
+// defaultCacheTTL is how long a cached result stays valid.
+const defaultCacheTTL = 30 * time.Minute
+
+// result is a cached ETA answer.
+type result struct {
+	duration time.Duration
+	distance float64
+}
+
+type cacheEntry struct {
+	result    result
+	expiresAt time.Time
+}
+
+// cache is a TTL-bounded, coordinate-keyed lookup table. It is safe for
+// concurrent use and periodically sweeps expired entries in the background.
+type cache struct {
+	ttl       time.Duration
+	precision int
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+func newCache(ttl time.Duration, precision int) *cache {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	if precision <= 0 {
+		precision = defaultGridPrecision
+	}
+	return &cache{
+		ttl:       ttl,
+		precision: precision,
+		entries:   make(map[string]cacheEntry),
+	}
+}
+
+// key builds the cache key for a from/to pair, rounding both coordinates to
+// the configured grid precision so nearby requests share a cache entry.
+func (c *cache) key(from, to Coordinate) string {
+	return c.encode(from) + "|" + c.encode(to)
+}
+
+func (c *cache) encode(p Coordinate) string {
+	return fmt.Sprintf("%.*f,%.*f", c.precision, p.Lat, c.precision, p.Lon)
+}
+
+func (c *cache) get(key string) (result, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return result{}, false
+	}
+	return entry.result, true
+}
+
+func (c *cache) set(key string, r result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cacheEntry{result: r, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// startSweeper periodically removes expired entries until ctx is cancelled.
+func (c *cache) startSweeper(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = c.ttl
+	}
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.sweep()
+			}
+		}
+	}()
+}
+
+func (c *cache) sweep() {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+		}
+	}
+}
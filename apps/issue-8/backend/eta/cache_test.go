@@ -0,0 +1,76 @@
+package eta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache_GetSetRoundTrip(t *testing.T) {
+	c := newCache(time.Minute, 4)
+	from := Coordinate{Lat: 24.1234567, Lon: 121.1234567}
+	to := Coordinate{Lat: 24.2, Lon: 121.2}
+
+	key := c.key(from, to)
+	if _, ok := c.get(key); ok {
+		t.Fatalf("expected a miss before set")
+	}
+
+	c.set(key, result{duration: 5 * time.Minute, distance: 10})
+
+	got, ok := c.get(key)
+	if !ok {
+		t.Fatalf("expected a hit after set")
+	}
+	if got.distance != 10 || got.duration != 5*time.Minute {
+		t.Errorf("got %+v, want distance=10 duration=5m", got)
+	}
+}
+
+func TestCache_KeyRoundsToGridPrecision(t *testing.T) {
+	c := newCache(time.Minute, 2)
+
+	// These differ only past the 2-decimal grid precision, so they should
+	// share a cache entry.
+	a := Coordinate{Lat: 24.001, Lon: 121.001}
+	b := Coordinate{Lat: 24.004, Lon: 121.004}
+	dest := Coordinate{Lat: 25.0, Lon: 122.0}
+
+	if c.key(a, dest) != c.key(b, dest) {
+		t.Errorf("expected coordinates within grid precision to share a cache key")
+	}
+}
+
+func TestCache_ExpiresAfterTTL(t *testing.T) {
+	c := newCache(10*time.Millisecond, 4)
+	from := Coordinate{Lat: 24.0, Lon: 121.0}
+	to := Coordinate{Lat: 24.1, Lon: 121.1}
+	key := c.key(from, to)
+
+	c.set(key, result{distance: 1})
+	if _, ok := c.get(key); !ok {
+		t.Fatalf("expected a hit immediately after set")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, ok := c.get(key); ok {
+		t.Errorf("expected entry to expire after its TTL")
+	}
+}
+
+func TestCache_SweepRemovesExpiredEntries(t *testing.T) {
+	c := newCache(10*time.Millisecond, 4)
+	key := c.key(Coordinate{Lat: 1, Lon: 1}, Coordinate{Lat: 2, Lon: 2})
+	c.set(key, result{distance: 1})
+
+	time.Sleep(20 * time.Millisecond)
+	c.sweep()
+
+	c.mu.RLock()
+	_, stillPresent := c.entries[key]
+	c.mu.RUnlock()
+
+	if stillPresent {
+		t.Errorf("expected sweep to remove the expired entry")
+	}
+}
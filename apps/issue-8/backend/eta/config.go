@@ -0,0 +1,58 @@
+package eta
+
+import (
+	"os"
+	"strconv"
+)
+
+// NewRouterFromEnv builds a Router using whichever provider credentials are
+// present in the environment:
+//
+//	GOOGLE_MAPS_API_KEY, GOOGLE_MONTHLY_QUOTA
+//	OSRM_BASE_URL (optional, defaults to the public demo server), OSRM_MONTHLY_QUOTA
+//	VALHALLA_BASE_URL (optional), VALHALLA_MONTHLY_QUOTA
+//	MAPBOX_API_KEY, MAPBOX_MONTHLY_QUOTA
+//
+// OSRM is always included even without configuration since its public demo
+// server requires no API key, giving the router at least one provider to
+// round-robin across.
+func NewRouterFromEnv() *Router {
+	var providers []Provider
+	quotas := map[string]int{}
+
+	if key := os.Getenv("GOOGLE_MAPS_API_KEY"); key != "" {
+		p := newGoogleProvider(key)
+		providers = append(providers, p)
+		quotas[p.Name()] = quotaFromEnv("GOOGLE_MONTHLY_QUOTA")
+	}
+
+	osrmProvider := newOSRMProvider(os.Getenv("OSRM_BASE_URL"))
+	providers = append(providers, osrmProvider)
+	quotas[osrmProvider.Name()] = quotaFromEnv("OSRM_MONTHLY_QUOTA")
+
+	if baseURL := os.Getenv("VALHALLA_BASE_URL"); baseURL != "" {
+		p := newValhallaProvider(baseURL)
+		providers = append(providers, p)
+		quotas[p.Name()] = quotaFromEnv("VALHALLA_MONTHLY_QUOTA")
+	}
+
+	if key := os.Getenv("MAPBOX_API_KEY"); key != "" {
+		p := newMapboxProvider(key)
+		providers = append(providers, p)
+		quotas[p.Name()] = quotaFromEnv("MAPBOX_MONTHLY_QUOTA")
+	}
+
+	return NewRouter(providers, quotas)
+}
+
+func quotaFromEnv(name string) int {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return 0
+	}
+	quota, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0
+	}
+	return quota
+}
@@ -0,0 +1,31 @@
+// Package eta provides a pluggable ETA (estimated time of arrival) subsystem
+// that can query multiple routing providers, cache their answers, coalesce
+// concurrent identical requests, and fall back to a straight-line estimate
+// when every provider is unavailable.
+package eta
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Coordinate is a latitude/longitude pair.
+type Coordinate struct {
+	Lat float64
+	Lon float64
+}
+
+// ErrQuotaExceeded should be returned by a Provider when it has hit its
+// rate limit or free-tier quota (e.g. an HTTP 429), so the Router knows to
+// try the next provider instead of treating it as a hard failure.
+var ErrQuotaExceeded = errors.New("eta: provider quota exceeded")
+
+// Provider abstracts a single routing/distance-matrix backend.
+type Provider interface {
+	// Name identifies the provider for logging and quota tracking.
+	Name() string
+	// DurationDistance returns the travel time and distance (in kilometers)
+	// between two coordinates.
+	DurationDistance(ctx context.Context, from, to Coordinate) (time.Duration, float64, error)
+}
@@ -0,0 +1,214 @@
+package eta
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// googleProvider queries the Google Distance Matrix API.
+type googleProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newGoogleProvider(apiKey string) *googleProvider {
+	return &googleProvider{apiKey: apiKey, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (p *googleProvider) Name() string { return "google" }
+
+func (p *googleProvider) DurationDistance(ctx context.Context, from, to Coordinate) (time.Duration, float64, error) {
+	url := fmt.Sprintf(
+		"https://maps.googleapis.com/maps/api/distancematrix/json?origins=%f,%f&destinations=%f,%f&key=%s",
+		from.Lat, from.Lon, to.Lat, to.Lon, p.apiKey,
+	)
+
+	var body struct {
+		Status string `json:"status"`
+		Rows   []struct {
+			Elements []struct {
+				Status   string `json:"status"`
+				Duration struct {
+					Value int `json:"value"` // seconds
+				} `json:"duration"`
+				Distance struct {
+					Value int `json:"value"` // meters
+				} `json:"distance"`
+			} `json:"elements"`
+		} `json:"rows"`
+	}
+
+	if err := getJSON(ctx, p.httpClient, url, &body); err != nil {
+		return 0, 0, err
+	}
+
+	if body.Status == "OVER_QUERY_LIMIT" {
+		return 0, 0, ErrQuotaExceeded
+	}
+	if len(body.Rows) == 0 || len(body.Rows[0].Elements) == 0 || body.Rows[0].Elements[0].Status != "OK" {
+		return 0, 0, fmt.Errorf("eta: google distance matrix returned no route")
+	}
+
+	elem := body.Rows[0].Elements[0]
+	return time.Duration(elem.Duration.Value) * time.Second, float64(elem.Distance.Value) / 1000.0, nil
+}
+
+// osrmProvider queries an OSRM (Open Source Routing Machine) instance.
+type osrmProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newOSRMProvider(baseURL string) *osrmProvider {
+	if baseURL == "" {
+		baseURL = "https://router.project-osrm.org"
+	}
+	return &osrmProvider{baseURL: baseURL, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (p *osrmProvider) Name() string { return "osrm" }
+
+func (p *osrmProvider) DurationDistance(ctx context.Context, from, to Coordinate) (time.Duration, float64, error) {
+	url := fmt.Sprintf("%s/route/v1/driving/%f,%f;%f,%f?overview=false",
+		p.baseURL, from.Lon, from.Lat, to.Lon, to.Lat)
+
+	var body struct {
+		Code   string `json:"code"`
+		Routes []struct {
+			Duration float64 `json:"duration"` // seconds
+			Distance float64 `json:"distance"` // meters
+		} `json:"routes"`
+	}
+
+	if err := getJSON(ctx, p.httpClient, url, &body); err != nil {
+		return 0, 0, err
+	}
+	if body.Code != "Ok" || len(body.Routes) == 0 {
+		return 0, 0, fmt.Errorf("eta: osrm returned no route (code=%s)", body.Code)
+	}
+
+	route := body.Routes[0]
+	return time.Duration(route.Duration) * time.Second, route.Distance / 1000.0, nil
+}
+
+// valhallaProvider queries a Valhalla routing instance.
+type valhallaProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newValhallaProvider(baseURL string) *valhallaProvider {
+	if baseURL == "" {
+		baseURL = "https://valhalla1.openstreetmap.de"
+	}
+	return &valhallaProvider{baseURL: baseURL, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (p *valhallaProvider) Name() string { return "valhalla" }
+
+func (p *valhallaProvider) DurationDistance(ctx context.Context, from, to Coordinate) (time.Duration, float64, error) {
+	reqBody := fmt.Sprintf(
+		`{"locations":[{"lat":%f,"lon":%f},{"lat":%f,"lon":%f}],"costing":"auto"}`,
+		from.Lat, from.Lon, to.Lat, to.Lon,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/route?json="+reqBody, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("eta: build valhalla request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return 0, 0, fmt.Errorf("eta: request valhalla: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return 0, 0, ErrQuotaExceeded
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, 0, fmt.Errorf("eta: valhalla returned %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Trip struct {
+			Summary struct {
+				Time   float64 `json:"time"`
+				Length float64 `json:"length"` // kilometers
+			} `json:"summary"`
+		} `json:"trip"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, 0, fmt.Errorf("eta: decode valhalla response: %w", err)
+	}
+
+	return time.Duration(body.Trip.Summary.Time) * time.Second, body.Trip.Summary.Length, nil
+}
+
+// mapboxProvider queries the Mapbox Directions API.
+type mapboxProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newMapboxProvider(apiKey string) *mapboxProvider {
+	return &mapboxProvider{apiKey: apiKey, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (p *mapboxProvider) Name() string { return "mapbox" }
+
+func (p *mapboxProvider) DurationDistance(ctx context.Context, from, to Coordinate) (time.Duration, float64, error) {
+	url := fmt.Sprintf(
+		"https://api.mapbox.com/directions/v5/mapbox/driving/%f,%f;%f,%f?access_token=%s",
+		from.Lon, from.Lat, to.Lon, to.Lat, p.apiKey,
+	)
+
+	var body struct {
+		Code   string `json:"code"`
+		Routes []struct {
+			Duration float64 `json:"duration"`
+			Distance float64 `json:"distance"`
+		} `json:"routes"`
+	}
+
+	if err := getJSON(ctx, p.httpClient, url, &body); err != nil {
+		return 0, 0, err
+	}
+	if body.Code != "Ok" || len(body.Routes) == 0 {
+		return 0, 0, fmt.Errorf("eta: mapbox returned no route (code=%s)", body.Code)
+	}
+
+	route := body.Routes[0]
+	return time.Duration(route.Duration) * time.Second, route.Distance / 1000.0, nil
+}
+
+// getJSON performs a GET request and decodes a JSON body, translating HTTP
+// 429 into ErrQuotaExceeded so the router can fall through to the next
+// provider.
+func getJSON(ctx context.Context, client *http.Client, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("eta: build request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("eta: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return ErrQuotaExceeded
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("eta: provider returned %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("eta: decode response: %w", err)
+	}
+	return nil
+}
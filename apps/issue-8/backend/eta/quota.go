@@ -0,0 +1,48 @@
+package eta
+
+import (
+	"sync"
+	"time"
+)
+
+// quotaTracker counts requests made against a provider's free-tier monthly
+// quota and resets automatically at the start of each calendar month.
+type quotaTracker struct {
+	monthlyLimit int
+
+	mu        sync.Mutex
+	used      int
+	resetedOn time.Month
+}
+
+func newQuotaTracker(monthlyLimit int) *quotaTracker {
+	return &quotaTracker{monthlyLimit: monthlyLimit, resetedOn: time.Now().Month()}
+}
+
+// allow reports whether another request may be made, resetting the counter
+// if the calendar month has rolled over. A zero or negative monthlyLimit
+// means unlimited.
+func (q *quotaTracker) allow() bool {
+	if q.monthlyLimit <= 0 {
+		return true
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if now := time.Now().Month(); now != q.resetedOn {
+		q.used = 0
+		q.resetedOn = now
+	}
+
+	return q.used < q.monthlyLimit
+}
+
+func (q *quotaTracker) record() {
+	if q.monthlyLimit <= 0 {
+		return
+	}
+	q.mu.Lock()
+	q.used++
+	q.mu.Unlock()
+}
@@ -0,0 +1,29 @@
+package eta
+
+import "testing"
+
+func TestQuotaTracker_AllowsUpToLimit(t *testing.T) {
+	q := newQuotaTracker(2)
+
+	if !q.allow() {
+		t.Fatalf("expected allow() to permit the 1st request")
+	}
+	q.record()
+	if !q.allow() {
+		t.Fatalf("expected allow() to permit the 2nd request")
+	}
+	q.record()
+	if q.allow() {
+		t.Errorf("expected allow() to deny the 3rd request once the monthly limit is used up")
+	}
+}
+
+func TestQuotaTracker_ZeroLimitIsUnlimited(t *testing.T) {
+	q := newQuotaTracker(0)
+	for i := 0; i < 100; i++ {
+		if !q.allow() {
+			t.Fatalf("expected allow() to always permit requests when monthlyLimit <= 0")
+		}
+		q.record()
+	}
+}
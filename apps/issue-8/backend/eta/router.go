@@ -0,0 +1,173 @@
+package eta
+
+import (
+	"context"
+	"log"
+	"math"
+	"sync"
+	"time"
+)
+
+// fallbackAvgSpeedKmh is the assumed average highway speed used to turn a
+// straight-line Haversine distance into a rough ETA when every provider has
+// failed.
+const fallbackAvgSpeedKmh = 90.0
+
+// Router fans ETA lookups out across one or more Provider backends,
+// round-robining between them and skipping over any that report
+// ErrQuotaExceeded, with a cache and request-coalescing layer in front and a
+// Haversine straight-line estimate as the last resort.
+type Router struct {
+	providers []Provider
+	quotas    map[string]*quotaTracker
+
+	cache *cache
+	group *singleflightGroup
+
+	mu   sync.Mutex
+	next int // round-robin cursor
+}
+
+// Option configures a Router.
+type Option func(*Router)
+
+// WithCacheTTL overrides the default 30 minute cache TTL.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(r *Router) { r.cache.ttl = ttl }
+}
+
+// WithGridPrecision overrides the default 4 decimal place cache-key rounding.
+func WithGridPrecision(precision int) Option {
+	return func(r *Router) { r.cache.precision = precision }
+}
+
+// NewRouter builds a Router from a list of providers and their quota
+// configuration, keyed by Provider.Name().
+func NewRouter(providers []Provider, quotas map[string]int, opts ...Option) *Router {
+	quotaTrackers := make(map[string]*quotaTracker, len(providers))
+	for _, p := range providers {
+		quotaTrackers[p.Name()] = newQuotaTracker(quotas[p.Name()])
+	}
+
+	r := &Router{
+		providers: providers,
+		quotas:    quotaTrackers,
+		cache:     newCache(defaultCacheTTL, defaultGridPrecision),
+		group:     newSingleflightGroup(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// StartCacheSweeper starts the background goroutine that evicts expired
+// cache entries. Call it once with a long-lived context.
+func (r *Router) StartCacheSweeper(ctx context.Context, interval time.Duration) {
+	r.cache.startSweeper(ctx, interval)
+}
+
+// DurationDistance returns the travel time and distance (kilometers)
+// between two coordinates, trying each configured provider in round-robin
+// order (skipping any over quota or returning ErrQuotaExceeded) before
+// falling back to a straight-line Haversine estimate.
+func (r *Router) DurationDistance(ctx context.Context, from, to Coordinate) (time.Duration, float64, error) {
+	key := r.cache.key(from, to)
+
+	if cached, ok := r.cache.get(key); ok {
+		return cached.duration, cached.distance, nil
+	}
+
+	res, err := r.group.do(key, func() (result, error) {
+		if cached, ok := r.cache.get(key); ok {
+			return cached, nil
+		}
+
+		res, usedFallback := r.queryProviders(ctx, from, to)
+		if !usedFallback {
+			r.cache.set(key, res)
+		}
+		return res, nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return res.duration, res.distance, nil
+}
+
+// queryProviders tries each provider once, in round-robin order, returning
+// the first success. If every provider fails or is over quota it falls back
+// to the Haversine straight-line estimate and reports that via the second
+// return value (so the caller knows not to cache it for the full TTL).
+func (r *Router) queryProviders(ctx context.Context, from, to Coordinate) (result, bool) {
+	order := r.roundRobinOrder()
+
+	for _, p := range order {
+		tracker := r.quotas[p.Name()]
+		if tracker != nil && !tracker.allow() {
+			continue
+		}
+
+		duration, distance, err := p.DurationDistance(ctx, from, to)
+		if err == nil {
+			if tracker != nil {
+				tracker.record()
+			}
+			return result{duration: duration, distance: distance}, false
+		}
+
+		if err == ErrQuotaExceeded {
+			log.Printf("eta: provider %s quota exceeded, trying next provider", p.Name())
+			continue
+		}
+		log.Printf("eta: provider %s failed: %v", p.Name(), err)
+	}
+
+	return r.haversineFallback(from, to), true
+}
+
+// roundRobinOrder returns the configured providers starting from the next
+// round-robin cursor position, so repeated calls spread load evenly.
+func (r *Router) roundRobinOrder() []Provider {
+	if len(r.providers) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	start := r.next
+	r.next = (r.next + 1) % len(r.providers)
+	r.mu.Unlock()
+
+	ordered := make([]Provider, len(r.providers))
+	for i := range r.providers {
+		ordered[i] = r.providers[(start+i)%len(r.providers)]
+	}
+	return ordered
+}
+
+// haversineFallback computes a straight-line ETA when no provider is
+// available, the same approach the handler used before this package
+// existed.
+func (r *Router) haversineFallback(from, to Coordinate) result {
+	distance := haversineDistanceKm(from, to)
+	hours := distance / fallbackAvgSpeedKmh
+	return result{duration: time.Duration(hours * float64(time.Hour)), distance: distance}
+}
+
+// haversineDistanceKm calculates the great-circle distance between two
+// coordinates in kilometers.
+func haversineDistanceKm(from, to Coordinate) float64 {
+	const earthRadiusKm = 6371.0
+
+	lat1 := from.Lat * math.Pi / 180
+	lat2 := to.Lat * math.Pi / 180
+	deltaLat := (to.Lat - from.Lat) * math.Pi / 180
+	deltaLon := (to.Lon - from.Lon) * math.Pi / 180
+
+	a := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLon/2)*math.Sin(deltaLon/2)
+	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+
+	return earthRadiusKm * c
+}
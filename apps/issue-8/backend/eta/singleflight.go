@@ -0,0 +1,45 @@
+package eta
+
+import "sync"
+
+// call represents an in-flight or completed singleflightGroup.do call.
+type call struct {
+	wg  sync.WaitGroup
+	val result
+	err error
+}
+
+// singleflightGroup coalesces concurrent calls sharing the same key into a
+// single execution of fn, so five identical ETA requests in flight at once
+// only reach the upstream provider once.
+type singleflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+func newSingleflightGroup() *singleflightGroup {
+	return &singleflightGroup{calls: make(map[string]*call)}
+}
+
+func (g *singleflightGroup) do(key string, fn func() (result, error)) (result, error) {
+	g.mu.Lock()
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+
+	c := new(call)
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	c.val, c.err = fn()
+	c.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return c.val, c.err
+}
@@ -0,0 +1,85 @@
+package eta
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleflightGroup_CoalescesConcurrentCalls(t *testing.T) {
+	g := newSingleflightGroup()
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	fn := func() (result, error) {
+		atomic.AddInt32(&calls, 1)
+		close(started)
+		<-release
+		return result{distance: 42}, nil
+	}
+
+	const n = 5
+	var wg sync.WaitGroup
+	results := make([]result, n)
+
+	// Launch the call that will actually run fn first, and wait until it's
+	// registered in the group (blocked on release) before launching the
+	// rest, so the other n-1 are guaranteed to find it in flight rather than
+	// racing to be first themselves.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r, _ := g.do("same-key", fn)
+		results[0] = r
+	}()
+	<-started
+
+	wg.Add(n - 1)
+	for i := 1; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			r, _ := g.do("same-key", fn)
+			results[i] = r
+		}(i)
+	}
+
+	// Give the other goroutines a chance to reach the in-flight call and
+	// start waiting on it before releasing fn, so they coalesce rather than
+	// racing fn's completion (and the resulting delete from the group).
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("fn called %d times, want exactly 1 for %d concurrent identical queries", got, n)
+	}
+	for i, r := range results {
+		if r.distance != 42 {
+			t.Errorf("results[%d].distance = %v, want 42", i, r.distance)
+		}
+	}
+}
+
+func TestSingleflightGroup_DistinctKeysRunIndependently(t *testing.T) {
+	g := newSingleflightGroup()
+
+	var calls int32
+	r1, _ := g.do("a", func() (result, error) {
+		atomic.AddInt32(&calls, 1)
+		return result{distance: 1}, nil
+	})
+	r2, _ := g.do("b", func() (result, error) {
+		atomic.AddInt32(&calls, 1)
+		return result{distance: 2}, nil
+	})
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("fn called %d times, want 2 for 2 distinct keys", got)
+	}
+	if r1.distance != 1 || r2.distance != 2 {
+		t.Errorf("got r1=%v r2=%v, want distinct results per key", r1, r2)
+	}
+}
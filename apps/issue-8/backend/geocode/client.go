@@ -0,0 +1,86 @@
+package geocode
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// reverseCacheKeyPrecision rounds coordinates before using them as a reverse
+// geocoding cache key. Service areas are static, so this is precise enough
+// to treat repeated lookups of the same area as cache hits.
+const reverseCacheKeyPrecision = 5
+
+// Client fails over across a list of geocoding backends in order, and
+// caches reverse-geocoding results indefinitely since the coordinates it's
+// asked about (service area locations) never move.
+type Client struct {
+	backends []Backend
+
+	mu           sync.RWMutex
+	reverseCache map[string]Address
+}
+
+// NewClient builds a Client that tries each backend in order, moving on to
+// the next on error.
+func NewClient(backends []Backend) *Client {
+	return &Client{backends: backends, reverseCache: make(map[string]Address)}
+}
+
+// Reverse converts a coordinate into a human-readable address, trying each
+// configured backend in order and caching the first success indefinitely.
+func (c *Client) Reverse(ctx context.Context, lat, lon float64) (Address, error) {
+	key := reverseCacheKey(lat, lon)
+
+	c.mu.RLock()
+	if addr, ok := c.reverseCache[key]; ok {
+		c.mu.RUnlock()
+		return addr, nil
+	}
+	c.mu.RUnlock()
+
+	var lastErr error
+	for _, b := range c.backends {
+		addr, err := b.Reverse(ctx, lat, lon)
+		if err != nil {
+			log.Printf("geocode: %s reverse geocoding failed: %v", b.Name(), err)
+			lastErr = err
+			continue
+		}
+
+		c.mu.Lock()
+		c.reverseCache[key] = addr
+		c.mu.Unlock()
+		return addr, nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("geocode: no backends configured")
+	}
+	return Address{}, lastErr
+}
+
+// Forward converts a free-text address into a coordinate, trying each
+// configured backend in order.
+func (c *Client) Forward(ctx context.Context, query string) (Coordinate, error) {
+	var lastErr error
+	for _, b := range c.backends {
+		coord, err := b.Forward(ctx, query)
+		if err != nil {
+			log.Printf("geocode: %s forward geocoding failed: %v", b.Name(), err)
+			lastErr = err
+			continue
+		}
+		return coord, nil
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("geocode: no backends configured")
+	}
+	return Coordinate{}, lastErr
+}
+
+func reverseCacheKey(lat, lon float64) string {
+	return fmt.Sprintf("%.*f,%.*f", reverseCacheKeyPrecision, lat, reverseCacheKeyPrecision, lon)
+}
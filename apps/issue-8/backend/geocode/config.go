@@ -0,0 +1,46 @@
+package geocode
+
+import (
+	"os"
+	"strings"
+)
+
+// NewClientFromEnv builds a Client from whichever provider API keys are
+// present, in GEOCODE_PROVIDERS order (a comma-separated list, e.g.
+// "google,amap"; defaults to trying all configured providers in the order
+// google, amap, baidu, qq).
+//
+//	GOOGLE_GEOCODING_API_KEY
+//	AMAP_API_KEY
+//	BAIDU_API_KEY
+//	QQ_MAPS_API_KEY
+func NewClientFromEnv() *Client {
+	available := map[string]Backend{}
+
+	if key := os.Getenv("GOOGLE_GEOCODING_API_KEY"); key != "" {
+		available["google"] = newGoogleBackend(key)
+	}
+	if key := os.Getenv("AMAP_API_KEY"); key != "" {
+		available["amap"] = newAmapBackend(key)
+	}
+	if key := os.Getenv("BAIDU_API_KEY"); key != "" {
+		available["baidu"] = newBaiduBackend(key)
+	}
+	if key := os.Getenv("QQ_MAPS_API_KEY"); key != "" {
+		available["qq"] = newQQBackend(key)
+	}
+
+	order := []string{"google", "amap", "baidu", "qq"}
+	if raw := os.Getenv("GEOCODE_PROVIDERS"); raw != "" {
+		order = strings.Split(raw, ",")
+	}
+
+	var backends []Backend
+	for _, name := range order {
+		if b, ok := available[strings.TrimSpace(name)]; ok {
+			backends = append(backends, b)
+		}
+	}
+
+	return NewClient(backends)
+}
@@ -0,0 +1,38 @@
+// Package geocode provides reverse and forward geocoding, backed by
+// whichever of Google, Amap, Baidu, or QQ Maps is configured, with
+// provider failover and indefinite caching of reverse-geocoded results
+// (service area locations are static, so their context never changes).
+package geocode
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoResult is returned by a Backend when the query matched nothing.
+var ErrNoResult = errors.New("geocode: no result")
+
+// Address is the enrichment context derived from reverse geocoding a
+// coordinate.
+type Address struct {
+	FormattedAddress string `json:"formattedAddress,omitempty"`
+	Township         string `json:"township,omitempty"`
+	District         string `json:"district,omitempty"`
+}
+
+// Coordinate is a latitude/longitude pair.
+type Coordinate struct {
+	Lat float64
+	Lon float64
+}
+
+// Backend abstracts a single geocoding provider.
+type Backend interface {
+	// Name identifies the provider for logging.
+	Name() string
+	// Reverse converts a coordinate into a human-readable address.
+	Reverse(ctx context.Context, lat, lon float64) (Address, error)
+	// Forward converts a free-text query (e.g. "台北市信義區") into a
+	// coordinate.
+	Forward(ctx context.Context, query string) (Coordinate, error)
+}
@@ -0,0 +1,294 @@
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// queryEscape percent-encodes a free-text query for use in a URL, so
+// addresses containing Chinese characters or spaces are sent correctly.
+func queryEscape(query string) string {
+	return url.QueryEscape(query)
+}
+
+// googleBackend uses the Google Geocoding API.
+type googleBackend struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newGoogleBackend(apiKey string) *googleBackend {
+	return &googleBackend{apiKey: apiKey, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (b *googleBackend) Name() string { return "google" }
+
+func (b *googleBackend) Reverse(ctx context.Context, lat, lon float64) (Address, error) {
+	url := fmt.Sprintf("https://maps.googleapis.com/maps/api/geocode/json?latlng=%f,%f&key=%s", lat, lon, b.apiKey)
+
+	var body struct {
+		Status  string `json:"status"`
+		Results []struct {
+			FormattedAddress  string `json:"formatted_address"`
+			AddressComponents []struct {
+				LongName string   `json:"long_name"`
+				Types    []string `json:"types"`
+			} `json:"address_components"`
+		} `json:"results"`
+	}
+	if err := getJSON(ctx, b.httpClient, url, &body); err != nil {
+		return Address{}, err
+	}
+	if body.Status != "OK" || len(body.Results) == 0 {
+		return Address{}, ErrNoResult
+	}
+
+	addr := Address{FormattedAddress: body.Results[0].FormattedAddress}
+	for _, comp := range body.Results[0].AddressComponents {
+		for _, t := range comp.Types {
+			switch t {
+			case "administrative_area_level_3":
+				addr.Township = comp.LongName
+			case "administrative_area_level_2":
+				addr.District = comp.LongName
+			}
+		}
+	}
+	return addr, nil
+}
+
+func (b *googleBackend) Forward(ctx context.Context, query string) (Coordinate, error) {
+	url := fmt.Sprintf("https://maps.googleapis.com/maps/api/geocode/json?address=%s&key=%s", queryEscape(query), b.apiKey)
+
+	var body struct {
+		Status  string `json:"status"`
+		Results []struct {
+			Geometry struct {
+				Location struct {
+					Lat float64 `json:"lat"`
+					Lng float64 `json:"lng"`
+				} `json:"location"`
+			} `json:"geometry"`
+		} `json:"results"`
+	}
+	if err := getJSON(ctx, b.httpClient, url, &body); err != nil {
+		return Coordinate{}, err
+	}
+	if body.Status != "OK" || len(body.Results) == 0 {
+		return Coordinate{}, ErrNoResult
+	}
+
+	loc := body.Results[0].Geometry.Location
+	return Coordinate{Lat: loc.Lat, Lon: loc.Lng}, nil
+}
+
+// amapBackend uses Amap's (高德地圖) geocoding API, commonly used for China
+// mainland coverage.
+type amapBackend struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newAmapBackend(apiKey string) *amapBackend {
+	return &amapBackend{apiKey: apiKey, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (b *amapBackend) Name() string { return "amap" }
+
+func (b *amapBackend) Reverse(ctx context.Context, lat, lon float64) (Address, error) {
+	url := fmt.Sprintf("https://restapi.amap.com/v3/geocode/regeo?location=%f,%f&key=%s", lon, lat, b.apiKey)
+
+	var body struct {
+		Status    string `json:"status"`
+		Regeocode struct {
+			FormattedAddress string `json:"formatted_address"`
+			AddressComponent struct {
+				Township string `json:"township"`
+				District string `json:"district"`
+			} `json:"addressComponent"`
+		} `json:"regeocode"`
+	}
+	if err := getJSON(ctx, b.httpClient, url, &body); err != nil {
+		return Address{}, err
+	}
+	if body.Status != "1" {
+		return Address{}, ErrNoResult
+	}
+
+	return Address{
+		FormattedAddress: body.Regeocode.FormattedAddress,
+		Township:         body.Regeocode.AddressComponent.Township,
+		District:         body.Regeocode.AddressComponent.District,
+	}, nil
+}
+
+func (b *amapBackend) Forward(ctx context.Context, query string) (Coordinate, error) {
+	url := fmt.Sprintf("https://restapi.amap.com/v3/geocode/geo?address=%s&key=%s", queryEscape(query), b.apiKey)
+
+	var body struct {
+		Status   string `json:"status"`
+		Geocodes []struct {
+			Location string `json:"location"` // "lon,lat"
+		} `json:"geocodes"`
+	}
+	if err := getJSON(ctx, b.httpClient, url, &body); err != nil {
+		return Coordinate{}, err
+	}
+	if body.Status != "1" || len(body.Geocodes) == 0 {
+		return Coordinate{}, ErrNoResult
+	}
+
+	var lon, lat float64
+	if _, err := fmt.Sscanf(body.Geocodes[0].Location, "%f,%f", &lon, &lat); err != nil {
+		return Coordinate{}, fmt.Errorf("geocode: parse amap location: %w", err)
+	}
+	return Coordinate{Lat: lat, Lon: lon}, nil
+}
+
+// baiduBackend uses Baidu Maps' geocoding API.
+type baiduBackend struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newBaiduBackend(apiKey string) *baiduBackend {
+	return &baiduBackend{apiKey: apiKey, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (b *baiduBackend) Name() string { return "baidu" }
+
+func (b *baiduBackend) Reverse(ctx context.Context, lat, lon float64) (Address, error) {
+	url := fmt.Sprintf("https://api.map.baidu.com/reverse_geocoding/v3/?location=%f,%f&output=json&ak=%s", lat, lon, b.apiKey)
+
+	var body struct {
+		Status int `json:"status"`
+		Result struct {
+			FormattedAddress string `json:"formatted_address"`
+			AddressComponent struct {
+				Town     string `json:"town"`
+				District string `json:"district"`
+			} `json:"addressComponent"`
+		} `json:"result"`
+	}
+	if err := getJSON(ctx, b.httpClient, url, &body); err != nil {
+		return Address{}, err
+	}
+	if body.Status != 0 {
+		return Address{}, ErrNoResult
+	}
+
+	return Address{
+		FormattedAddress: body.Result.FormattedAddress,
+		Township:         body.Result.AddressComponent.Town,
+		District:         body.Result.AddressComponent.District,
+	}, nil
+}
+
+func (b *baiduBackend) Forward(ctx context.Context, query string) (Coordinate, error) {
+	url := fmt.Sprintf("https://api.map.baidu.com/geocoding/v3/?address=%s&output=json&ak=%s", queryEscape(query), b.apiKey)
+
+	var body struct {
+		Status int `json:"status"`
+		Result struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"result"`
+	}
+	if err := getJSON(ctx, b.httpClient, url, &body); err != nil {
+		return Coordinate{}, err
+	}
+	if body.Status != 0 {
+		return Coordinate{}, ErrNoResult
+	}
+
+	return Coordinate{Lat: body.Result.Location.Lat, Lon: body.Result.Location.Lng}, nil
+}
+
+// qqBackend uses Tencent (QQ Maps) geocoding API.
+type qqBackend struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newQQBackend(apiKey string) *qqBackend {
+	return &qqBackend{apiKey: apiKey, httpClient: &http.Client{Timeout: 5 * time.Second}}
+}
+
+func (b *qqBackend) Name() string { return "qq" }
+
+func (b *qqBackend) Reverse(ctx context.Context, lat, lon float64) (Address, error) {
+	url := fmt.Sprintf("https://apis.map.qq.com/ws/geocoder/v1/?location=%f,%f&key=%s", lat, lon, b.apiKey)
+
+	var body struct {
+		Status int `json:"status"`
+		Result struct {
+			Address          string `json:"address"`
+			AddressComponent struct {
+				Town     string `json:"town"`
+				District string `json:"district"`
+			} `json:"address_component"`
+		} `json:"result"`
+	}
+	if err := getJSON(ctx, b.httpClient, url, &body); err != nil {
+		return Address{}, err
+	}
+	if body.Status != 0 {
+		return Address{}, ErrNoResult
+	}
+
+	return Address{
+		FormattedAddress: body.Result.Address,
+		Township:         body.Result.AddressComponent.Town,
+		District:         body.Result.AddressComponent.District,
+	}, nil
+}
+
+func (b *qqBackend) Forward(ctx context.Context, query string) (Coordinate, error) {
+	url := fmt.Sprintf("https://apis.map.qq.com/ws/geocoder/v1/?address=%s&key=%s", queryEscape(query), b.apiKey)
+
+	var body struct {
+		Status int `json:"status"`
+		Result struct {
+			Location struct {
+				Lat float64 `json:"lat"`
+				Lng float64 `json:"lng"`
+			} `json:"location"`
+		} `json:"result"`
+	}
+	if err := getJSON(ctx, b.httpClient, url, &body); err != nil {
+		return Coordinate{}, err
+	}
+	if body.Status != 0 {
+		return Coordinate{}, ErrNoResult
+	}
+
+	return Coordinate{Lat: body.Result.Location.Lat, Lon: body.Result.Location.Lng}, nil
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("geocode: build request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("geocode: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("geocode: provider returned %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("geocode: decode response: %w", err)
+	}
+	return nil
+}
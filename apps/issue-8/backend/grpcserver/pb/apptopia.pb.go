@@ -0,0 +1,308 @@
+// Hand-written stand-ins for proto/apptopia.proto's message types.
+//
+// protoc / protoc-gen-go aren't available in this environment, so these are
+// written by hand to match protoc-gen-go's output shape (same field tags,
+// same Get* accessors). If protoc becomes available, regenerate with:
+//
+//	protoc --go_out=. --go-grpc_out=. proto/apptopia.proto
+//
+// and delete this file and apptopia_grpc.pb.go.
+
+package pb
+
+import "fmt"
+
+type LocationRequest struct {
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Heading   float64 `protobuf:"fixed64,3,opt,name=heading,proto3" json:"heading,omitempty"`
+	Speed     float64 `protobuf:"fixed64,4,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *LocationRequest) Reset() { *x = LocationRequest{} }
+func (x *LocationRequest) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("latitude:%v longitude:%v heading:%v speed:%v", x.Latitude, x.Longitude, x.Heading, x.Speed)
+}
+func (*LocationRequest) ProtoMessage() {}
+
+func (x *LocationRequest) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *LocationRequest) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *LocationRequest) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *LocationRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+// LocationUpdate is one GPS fix in a StreamNearestServiceArea call.
+type LocationUpdate struct {
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Heading   float64 `protobuf:"fixed64,3,opt,name=heading,proto3" json:"heading,omitempty"`
+	Speed     float64 `protobuf:"fixed64,4,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *LocationUpdate) Reset() { *x = LocationUpdate{} }
+func (x *LocationUpdate) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("latitude:%v longitude:%v heading:%v speed:%v", x.Latitude, x.Longitude, x.Heading, x.Speed)
+}
+func (*LocationUpdate) ProtoMessage() {}
+
+func (x *LocationUpdate) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *LocationUpdate) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *LocationUpdate) GetHeading() float64 {
+	if x != nil {
+		return x.Heading
+	}
+	return 0
+}
+
+func (x *LocationUpdate) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type ParkingInfo struct {
+	Status          string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	AvailableSpaces int32  `protobuf:"varint,2,opt,name=available_spaces,json=availableSpaces,proto3" json:"available_spaces,omitempty"`
+	TotalSpaces     int32  `protobuf:"varint,3,opt,name=total_spaces,json=totalSpaces,proto3" json:"total_spaces,omitempty"`
+	ColorCode       string `protobuf:"bytes,4,opt,name=color_code,json=colorCode,proto3" json:"color_code,omitempty"`
+}
+
+func (x *ParkingInfo) Reset() { *x = ParkingInfo{} }
+func (x *ParkingInfo) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("status:%q available_spaces:%v total_spaces:%v color_code:%q", x.Status, x.AvailableSpaces, x.TotalSpaces, x.ColorCode)
+}
+func (*ParkingInfo) ProtoMessage() {}
+
+func (x *ParkingInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ParkingInfo) GetAvailableSpaces() int32 {
+	if x != nil {
+		return x.AvailableSpaces
+	}
+	return 0
+}
+
+func (x *ParkingInfo) GetTotalSpaces() int32 {
+	if x != nil {
+		return x.TotalSpaces
+	}
+	return 0
+}
+
+func (x *ParkingInfo) GetColorCode() string {
+	if x != nil {
+		return x.ColorCode
+	}
+	return ""
+}
+
+type ServiceArea struct {
+	Id          string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Direction   string       `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	Highway     string       `protobuf:"bytes,4,opt,name=highway,proto3" json:"highway,omitempty"`
+	Latitude    float64      `protobuf:"fixed64,5,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude   float64      `protobuf:"fixed64,6,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Mileage     float64      `protobuf:"fixed64,7,opt,name=mileage,proto3" json:"mileage,omitempty"`
+	Distance    float64      `protobuf:"fixed64,8,opt,name=distance,proto3" json:"distance,omitempty"`
+	Eta         string       `protobuf:"bytes,9,opt,name=eta,proto3" json:"eta,omitempty"`
+	ParkingInfo *ParkingInfo `protobuf:"bytes,10,opt,name=parking_info,json=parkingInfo,proto3" json:"parking_info,omitempty"`
+}
+
+func (x *ServiceArea) Reset() { *x = ServiceArea{} }
+func (x *ServiceArea) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("id:%q name:%q direction:%q highway:%q latitude:%v longitude:%v mileage:%v distance:%v eta:%q parking_info:<%v>",
+		x.Id, x.Name, x.Direction, x.Highway, x.Latitude, x.Longitude, x.Mileage, x.Distance, x.Eta, x.ParkingInfo)
+}
+func (*ServiceArea) ProtoMessage() {}
+
+func (x *ServiceArea) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ServiceArea) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ServiceArea) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *ServiceArea) GetHighway() string {
+	if x != nil {
+		return x.Highway
+	}
+	return ""
+}
+
+func (x *ServiceArea) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *ServiceArea) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *ServiceArea) GetMileage() float64 {
+	if x != nil {
+		return x.Mileage
+	}
+	return 0
+}
+
+func (x *ServiceArea) GetDistance() float64 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *ServiceArea) GetEta() string {
+	if x != nil {
+		return x.Eta
+	}
+	return ""
+}
+
+func (x *ServiceArea) GetParkingInfo() *ParkingInfo {
+	if x != nil {
+		return x.ParkingInfo
+	}
+	return nil
+}
+
+// ServiceAreaUpdate is one response in a StreamNearestServiceArea call.
+type ServiceAreaUpdate struct {
+	ServiceArea *ServiceArea `protobuf:"bytes,1,opt,name=service_area,json=serviceArea,proto3" json:"service_area,omitempty"`
+	Error       string       `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *ServiceAreaUpdate) Reset() { *x = ServiceAreaUpdate{} }
+func (x *ServiceAreaUpdate) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("service_area:<%v> error:%q", x.ServiceArea, x.Error)
+}
+func (*ServiceAreaUpdate) ProtoMessage() {}
+
+func (x *ServiceAreaUpdate) GetServiceArea() *ServiceArea {
+	if x != nil {
+		return x.ServiceArea
+	}
+	return nil
+}
+
+func (x *ServiceAreaUpdate) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type HighwayFilter struct {
+	Highway string `protobuf:"bytes,1,opt,name=highway,proto3" json:"highway,omitempty"`
+}
+
+func (x *HighwayFilter) Reset() { *x = HighwayFilter{} }
+func (x *HighwayFilter) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("highway:%q", x.Highway)
+}
+func (*HighwayFilter) ProtoMessage() {}
+
+func (x *HighwayFilter) GetHighway() string {
+	if x != nil {
+		return x.Highway
+	}
+	return ""
+}
+
+type ServiceAreaList struct {
+	ServiceAreas []*ServiceArea `protobuf:"bytes,1,rep,name=service_areas,json=serviceAreas,proto3" json:"service_areas,omitempty"`
+}
+
+func (x *ServiceAreaList) Reset() { *x = ServiceAreaList{} }
+func (x *ServiceAreaList) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("service_areas:%v", x.ServiceAreas)
+}
+func (*ServiceAreaList) ProtoMessage() {}
+
+func (x *ServiceAreaList) GetServiceAreas() []*ServiceArea {
+	if x != nil {
+		return x.ServiceAreas
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Hand-written stand-in for the protoc-gen-go-grpc output of
+// proto/apptopia.proto.
+//
+// protoc / protoc-gen-go-grpc aren't available in this environment, so this
+// is written by hand to match protoc-gen-go-grpc's output shape (same
+// ServiceDesc, same client/server interfaces). If protoc becomes available,
+// regenerate with:
+//
+//	protoc --go_out=. --go-grpc_out=. proto/apptopia.proto
+//
+// and delete this file and apptopia.pb.go.
+
+package pb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	ApptopiaService_GetNearest_FullMethodName               = "/apptopia.ApptopiaService/GetNearest"
+	ApptopiaService_ListServiceAreas_FullMethodName         = "/apptopia.ApptopiaService/ListServiceAreas"
+	ApptopiaService_StreamNearestServiceArea_FullMethodName = "/apptopia.ApptopiaService/StreamNearestServiceArea"
+)
+
+// ApptopiaServiceClient is the client API for ApptopiaService.
+type ApptopiaServiceClient interface {
+	GetNearest(ctx context.Context, in *LocationRequest, opts ...grpc.CallOption) (*ServiceArea, error)
+	ListServiceAreas(ctx context.Context, in *HighwayFilter, opts ...grpc.CallOption) (*ServiceAreaList, error)
+	StreamNearestServiceArea(ctx context.Context, opts ...grpc.CallOption) (ApptopiaService_StreamNearestServiceAreaClient, error)
+}
+
+type apptopiaServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+// NewApptopiaServiceClient builds a client for ApptopiaService.
+func NewApptopiaServiceClient(cc grpc.ClientConnInterface) ApptopiaServiceClient {
+	return &apptopiaServiceClient{cc}
+}
+
+func (c *apptopiaServiceClient) GetNearest(ctx context.Context, in *LocationRequest, opts ...grpc.CallOption) (*ServiceArea, error) {
+	out := new(ServiceArea)
+	if err := c.cc.Invoke(ctx, ApptopiaService_GetNearest_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apptopiaServiceClient) ListServiceAreas(ctx context.Context, in *HighwayFilter, opts ...grpc.CallOption) (*ServiceAreaList, error) {
+	out := new(ServiceAreaList)
+	if err := c.cc.Invoke(ctx, ApptopiaService_ListServiceAreas_FullMethodName, in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apptopiaServiceClient) StreamNearestServiceArea(ctx context.Context, opts ...grpc.CallOption) (ApptopiaService_StreamNearestServiceAreaClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ApptopiaService_ServiceDesc.Streams[0], ApptopiaService_StreamNearestServiceArea_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &apptopiaServiceStreamNearestServiceAreaClient{stream}, nil
+}
+
+// ApptopiaService_StreamNearestServiceAreaClient is the client-side stream handle.
+type ApptopiaService_StreamNearestServiceAreaClient interface {
+	Send(*LocationUpdate) error
+	Recv() (*ServiceAreaUpdate, error)
+	grpc.ClientStream
+}
+
+type apptopiaServiceStreamNearestServiceAreaClient struct {
+	grpc.ClientStream
+}
+
+func (x *apptopiaServiceStreamNearestServiceAreaClient) Send(m *LocationUpdate) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *apptopiaServiceStreamNearestServiceAreaClient) Recv() (*ServiceAreaUpdate, error) {
+	m := new(ServiceAreaUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ApptopiaServiceServer is the server API for ApptopiaService.
+type ApptopiaServiceServer interface {
+	GetNearest(context.Context, *LocationRequest) (*ServiceArea, error)
+	ListServiceAreas(context.Context, *HighwayFilter) (*ServiceAreaList, error)
+	StreamNearestServiceArea(ApptopiaService_StreamNearestServiceAreaServer) error
+}
+
+// UnimplementedApptopiaServiceServer can be embedded to have forward
+// compatible implementations.
+type UnimplementedApptopiaServiceServer struct{}
+
+func (UnimplementedApptopiaServiceServer) GetNearest(context.Context, *LocationRequest) (*ServiceArea, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNearest not implemented")
+}
+
+func (UnimplementedApptopiaServiceServer) ListServiceAreas(context.Context, *HighwayFilter) (*ServiceAreaList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServiceAreas not implemented")
+}
+
+func (UnimplementedApptopiaServiceServer) StreamNearestServiceArea(ApptopiaService_StreamNearestServiceAreaServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamNearestServiceArea not implemented")
+}
+
+// ApptopiaService_StreamNearestServiceAreaServer is the server-side stream handle.
+type ApptopiaService_StreamNearestServiceAreaServer interface {
+	Send(*ServiceAreaUpdate) error
+	Recv() (*LocationUpdate, error)
+	grpc.ServerStream
+}
+
+type apptopiaServiceStreamNearestServiceAreaServer struct {
+	grpc.ServerStream
+}
+
+func (x *apptopiaServiceStreamNearestServiceAreaServer) Send(m *ServiceAreaUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *apptopiaServiceStreamNearestServiceAreaServer) Recv() (*LocationUpdate, error) {
+	m := new(LocationUpdate)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RegisterApptopiaServiceServer registers srv on s.
+func RegisterApptopiaServiceServer(s grpc.ServiceRegistrar, srv ApptopiaServiceServer) {
+	s.RegisterService(&ApptopiaService_ServiceDesc, srv)
+}
+
+func _ApptopiaService_GetNearest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApptopiaServiceServer).GetNearest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ApptopiaService_GetNearest_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApptopiaServiceServer).GetNearest(ctx, req.(*LocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApptopiaService_ListServiceAreas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HighwayFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApptopiaServiceServer).ListServiceAreas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: ApptopiaService_ListServiceAreas_FullMethodName}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApptopiaServiceServer).ListServiceAreas(ctx, req.(*HighwayFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApptopiaService_StreamNearestServiceArea_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ApptopiaServiceServer).StreamNearestServiceArea(&apptopiaServiceStreamNearestServiceAreaServer{stream})
+}
+
+// ApptopiaService_ServiceDesc is the grpc.ServiceDesc for ApptopiaService.
+var ApptopiaService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apptopia.ApptopiaService",
+	HandlerType: (*ApptopiaServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetNearest", Handler: _ApptopiaService_GetNearest_Handler},
+		{MethodName: "ListServiceAreas", Handler: _ApptopiaService_ListServiceAreas_Handler},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamNearestServiceArea",
+			Handler:       _ApptopiaService_StreamNearestServiceArea_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/apptopia.proto",
+}
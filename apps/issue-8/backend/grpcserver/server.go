@@ -0,0 +1,144 @@
+// Package grpcserver exposes core.Service over gRPC, alongside the HTTP API,
+// so mobile clients can use a unary call or a long-lived streaming RPC
+// instead of re-polling.
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/core"
+	"github.com/doggy8088/Apptopia/apps/issue-8/backend/grpcserver/pb"
+)
+
+// Server implements pb.ApptopiaServiceServer on top of a core.Service.
+type Server struct {
+	pb.UnimplementedApptopiaServiceServer
+	svc *core.Service
+}
+
+// New builds a gRPC server backed by svc.
+func New(svc *core.Service) *Server {
+	return &Server{svc: svc}
+}
+
+// ListenAndServe starts a gRPC listener on addr and blocks until it stops.
+func (s *Server) ListenAndServe(addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	grpcSrv := grpc.NewServer()
+	pb.RegisterApptopiaServiceServer(grpcSrv, s)
+
+	return grpcSrv.Serve(lis)
+}
+
+// GetNearest returns the single nearest service area ahead of the driver.
+func (s *Server) GetNearest(ctx context.Context, req *pb.LocationRequest) (*pb.ServiceArea, error) {
+	nearest, err := s.svc.FindNearest(ctx, toLocationRequest(req))
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+	return toPBServiceArea(nearest), nil
+}
+
+// ListServiceAreas lists all known service areas, optionally filtered to one
+// highway.
+func (s *Server) ListServiceAreas(ctx context.Context, req *pb.HighwayFilter) (*pb.ServiceAreaList, error) {
+	areas := s.svc.ListServiceAreas(req.GetHighway())
+
+	out := &pb.ServiceAreaList{ServiceAreas: make([]*pb.ServiceArea, len(areas))}
+	for i := range areas {
+		out.ServiceAreas[i] = toPBServiceArea(&areas[i])
+	}
+	return out, nil
+}
+
+// StreamNearestServiceArea receives a stream of GPS fixes and pushes back an
+// updated nearest-service-area answer on every fix, so the client doesn't
+// have to re-poll.
+func (s *Server) StreamNearestServiceArea(stream pb.ApptopiaService_StreamNearestServiceAreaServer) error {
+	ctx := stream.Context()
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		req := core.LocationRequest{
+			Latitude:  update.GetLatitude(),
+			Longitude: update.GetLongitude(),
+			Heading:   update.GetHeading(),
+			Speed:     update.GetSpeed(),
+		}
+
+		nearest, err := s.svc.FindNearest(ctx, req)
+		resp := &pb.ServiceAreaUpdate{}
+		if err != nil {
+			resp.Error = err.Error()
+		} else {
+			resp.ServiceArea = toPBServiceArea(nearest)
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func toLocationRequest(req *pb.LocationRequest) core.LocationRequest {
+	return core.LocationRequest{
+		Latitude:  req.GetLatitude(),
+		Longitude: req.GetLongitude(),
+		Heading:   req.GetHeading(),
+		Speed:     req.GetSpeed(),
+	}
+}
+
+func toPBServiceArea(area *core.ServiceArea) *pb.ServiceArea {
+	if area == nil {
+		return nil
+	}
+
+	out := &pb.ServiceArea{
+		Id:        area.ID,
+		Name:      area.Name,
+		Direction: area.Direction,
+		Highway:   area.Highway,
+		Latitude:  area.Latitude,
+		Longitude: area.Longitude,
+		Mileage:   area.Mileage,
+		Distance:  area.Distance,
+		Eta:       area.ETA,
+	}
+	if area.ParkingInfo != nil {
+		out.ParkingInfo = &pb.ParkingInfo{
+			Status:          area.ParkingInfo.Status,
+			AvailableSpaces: int32(area.ParkingInfo.AvailableSpaces),
+			TotalSpaces:     int32(area.ParkingInfo.TotalSpaces),
+			ColorCode:       area.ParkingInfo.ColorCode,
+		}
+	}
+	return out
+}
+
+func toGRPCError(err error) error {
+	switch {
+	case errors.Is(err, core.ErrNotOnFreeway):
+		return status.Error(codes.InvalidArgument, "偵測到您目前不在高速公路上，本功能僅限國道急尿使用。")
+	case errors.Is(err, core.ErrNoServiceAreaFound):
+		return status.Error(codes.NotFound, "找不到附近的服務區或休息站")
+	case errors.Is(err, core.ErrAddressNotFound):
+		return status.Error(codes.InvalidArgument, "無法解析地址，請確認輸入內容")
+	default:
+		return status.Error(codes.Internal, "伺服器發生錯誤")
+	}
+}
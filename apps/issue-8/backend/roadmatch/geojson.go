@@ -0,0 +1,68 @@
+package roadmatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// geoJSON mirrors the subset of the GeoJSON FeatureCollection spec produced
+// by cmd/roadmatch-preprocess: one LineString Feature per direction of
+// travel between two mileage markers.
+type geoJSON struct {
+	Type     string        `json:"type"`
+	Features []geoJSONFeat `json:"features"`
+}
+
+type geoJSONFeat struct {
+	Type       string `json:"type"`
+	Properties struct {
+		Highway   string  `json:"highway"`
+		Direction string  `json:"direction"`
+		Mileage   float64 `json:"mileage"`
+	} `json:"properties"`
+	Geometry struct {
+		Type        string       `json:"type"`        // "LineString"
+		Coordinates [][2]float64 `json:"coordinates"` // [lon, lat] pairs, per GeoJSON convention
+	} `json:"geometry"`
+}
+
+// LoadGeoJSON reads a preprocessed freeway centerline GeoJSON file (as
+// produced by cmd/roadmatch-preprocess) and builds a spatial Index over its
+// segments.
+func LoadGeoJSON(path string) (*Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("roadmatch: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	return loadGeoJSON(f)
+}
+
+func loadGeoJSON(r io.Reader) (*Index, error) {
+	var doc geoJSON
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("roadmatch: decode geojson: %w", err)
+	}
+
+	var segments []Segment
+	for _, feat := range doc.Features {
+		if feat.Geometry.Type != "LineString" {
+			continue
+		}
+		coords := feat.Geometry.Coordinates
+		for i := 0; i+1 < len(coords); i++ {
+			segments = append(segments, Segment{
+				Highway:   feat.Properties.Highway,
+				Direction: feat.Properties.Direction,
+				Mileage:   feat.Properties.Mileage,
+				Start:     Point{Lat: coords[i][1], Lon: coords[i][0]},
+				End:       Point{Lat: coords[i+1][1], Lon: coords[i+1][0]},
+			})
+		}
+	}
+
+	return NewIndex(segments), nil
+}
@@ -0,0 +1,216 @@
+// Package roadmatch snaps a GPS fix onto Taiwan's freeway centerline
+// network, so the API can tell whether a user is actually on a freeway
+// (rather than merely inside a bounding box) and can derive their highway,
+// direction of travel, and mileage from the matched segment.
+package roadmatch
+
+import "math"
+
+// MaxSnapDistanceMeters is how far a GPS fix may be from the nearest
+// centerline segment before it is considered off-freeway.
+const MaxSnapDistanceMeters = 50.0
+
+// gridCellSizeDeg is the grid index cell size in degrees, roughly 1.1km at
+// Taiwan's latitude — small enough to keep per-cell segment counts low.
+const gridCellSizeDeg = 0.01
+
+// Point is a latitude/longitude pair.
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+// Segment is one piece of freeway centerline between two consecutive
+// mileage markers, carrying enough metadata to answer a snap query without
+// looking anything else up.
+type Segment struct {
+	Highway   string  // 國道1號, 國道3號, etc.
+	Direction string  // 北上 or 南下
+	Mileage   float64 // mileage marker at the start of the segment, in km
+	Start     Point
+	End       Point
+}
+
+// heading returns the compass bearing (0-360, 0 = North) of travel from
+// Start to End.
+func (s Segment) heading() float64 {
+	dLon := (s.End.Lon - s.Start.Lon) * math.Pi / 180
+	lat1 := s.Start.Lat * math.Pi / 180
+	lat2 := s.End.Lat * math.Pi / 180
+
+	y := math.Sin(dLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLon)
+	bearing := math.Atan2(y, x) * 180 / math.Pi
+
+	return math.Mod(bearing+360, 360)
+}
+
+// Index is an in-memory spatial index over freeway centerline segments,
+// bucketed into a uniform grid for fast nearest-segment lookups.
+type Index struct {
+	cells map[cellKey][]Segment
+}
+
+type cellKey struct {
+	x, y int
+}
+
+// NewIndex builds a grid index over the given segments.
+func NewIndex(segments []Segment) *Index {
+	idx := &Index{cells: make(map[cellKey][]Segment)}
+	for _, seg := range segments {
+		idx.insert(seg)
+	}
+	return idx
+}
+
+func (idx *Index) insert(seg Segment) {
+	for _, key := range cellsForSegment(seg) {
+		idx.cells[key] = append(idx.cells[key], seg)
+	}
+}
+
+func cellKeyFor(p Point) cellKey {
+	return cellKey{
+		x: int(math.Floor(p.Lon / gridCellSizeDeg)),
+		y: int(math.Floor(p.Lat / gridCellSizeDeg)),
+	}
+}
+
+// cellsForSegment returns every grid cell a segment's bounding box touches,
+// so a lookup near either endpoint finds it regardless of which cell the
+// query point falls in.
+func cellsForSegment(seg Segment) []cellKey {
+	startKey := cellKeyFor(seg.Start)
+	endKey := cellKeyFor(seg.End)
+
+	minX, maxX := startKey.x, endKey.x
+	if minX > maxX {
+		minX, maxX = maxX, minX
+	}
+	minY, maxY := startKey.y, endKey.y
+	if minY > maxY {
+		minY, maxY = maxY, minY
+	}
+
+	var keys []cellKey
+	for x := minX; x <= maxX; x++ {
+		for y := minY; y <= maxY; y++ {
+			keys = append(keys, cellKey{x: x, y: y})
+		}
+	}
+	return keys
+}
+
+// candidates returns the segments in the query point's cell and its
+// immediate neighbours.
+func (idx *Index) candidates(p Point) []Segment {
+	center := cellKeyFor(p)
+	var out []Segment
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			out = append(out, idx.cells[cellKey{x: center.x + dx, y: center.y + dy}]...)
+		}
+	}
+	return out
+}
+
+// Match is the result of snapping a GPS fix to the freeway network.
+type Match struct {
+	Highway   string
+	Direction string
+	Mileage   float64
+	OnFreeway bool
+}
+
+// SnapToHighway finds the nearest freeway centerline segment to (lat, lon),
+// using the given heading to pick between directions at the same location,
+// and reports whether the fix is within MaxSnapDistanceMeters of it.
+func (idx *Index) SnapToHighway(lat, lon, heading float64) Match {
+	p := Point{Lat: lat, Lon: lon}
+
+	var best Segment
+	bestDist := math.Inf(1)
+	found := false
+
+	for _, seg := range idx.candidates(p) {
+		dist := distanceToSegmentMeters(p, seg)
+		if dist < bestDist {
+			bestDist = dist
+			best = seg
+			found = true
+		}
+	}
+
+	if !found || bestDist > MaxSnapDistanceMeters {
+		return Match{OnFreeway: false}
+	}
+
+	// Among segments at essentially the same spot, prefer the one whose
+	// tangent best agrees with the driver's heading, since opposite
+	// directions of the same freeway run right next to each other.
+	direction := best.Direction
+	if headingDelta(heading, best.heading()) > 90 {
+		direction = oppositeDirection(direction)
+	}
+
+	return Match{
+		Highway:   best.Highway,
+		Direction: direction,
+		Mileage:   best.Mileage,
+		OnFreeway: true,
+	}
+}
+
+func oppositeDirection(direction string) string {
+	if direction == "北上" {
+		return "南下"
+	}
+	return "北上"
+}
+
+// headingDelta returns the smallest angle between two compass bearings.
+func headingDelta(a, b float64) float64 {
+	delta := math.Abs(a - b)
+	if delta > 180 {
+		delta = 360 - delta
+	}
+	return delta
+}
+
+// distanceToSegmentMeters approximates the distance from a point to a
+// segment by projecting onto an equirectangular plane, which is accurate
+// enough at freeway-segment scale (a few hundred meters).
+func distanceToSegmentMeters(p Point, seg Segment) float64 {
+	const metersPerDegreeLat = 111320.0
+	metersPerDegreeLon := metersPerDegreeLat * math.Cos(p.Lat*math.Pi/180)
+
+	toXY := func(pt Point) (float64, float64) {
+		return pt.Lon * metersPerDegreeLon, pt.Lat * metersPerDegreeLat
+	}
+
+	px, py := toXY(p)
+	ax, ay := toXY(seg.Start)
+	bx, by := toXY(seg.End)
+
+	abx, aby := bx-ax, by-ay
+	apx, apy := px-ax, py-ay
+
+	lenSq := abx*abx + aby*aby
+	t := 0.0
+	if lenSq > 0 {
+		t = (apx*abx + apy*aby) / lenSq
+		if t < 0 {
+			t = 0
+		} else if t > 1 {
+			t = 1
+		}
+	}
+
+	closestX := ax + t*abx
+	closestY := ay + t*aby
+
+	dx := px - closestX
+	dy := py - closestY
+	return math.Sqrt(dx*dx + dy*dy)
+}
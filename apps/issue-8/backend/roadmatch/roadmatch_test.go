@@ -0,0 +1,72 @@
+package roadmatch
+
+import "testing"
+
+func straightSegment() Segment {
+	return Segment{
+		Highway:   "國道1號",
+		Direction: "北上",
+		Mileage:   100.0,
+		Start:     Point{Lat: 24.0, Lon: 121.0},
+		End:       Point{Lat: 24.01, Lon: 121.0}, // due north
+	}
+}
+
+func TestSnapToHighway_WithinRange(t *testing.T) {
+	idx := NewIndex([]Segment{straightSegment()})
+
+	// ~5m east of the segment's midpoint, well within MaxSnapDistanceMeters.
+	match := idx.SnapToHighway(24.005, 121.00005, 0)
+
+	if !match.OnFreeway {
+		t.Fatalf("expected OnFreeway=true for a fix close to the centerline")
+	}
+	if match.Highway != "國道1號" {
+		t.Errorf("Highway = %q, want 國道1號", match.Highway)
+	}
+	if match.Mileage != 100.0 {
+		t.Errorf("Mileage = %v, want 100.0", match.Mileage)
+	}
+}
+
+func TestSnapToHighway_OutOfRange(t *testing.T) {
+	idx := NewIndex([]Segment{straightSegment()})
+
+	// ~1km east of the centerline, well past MaxSnapDistanceMeters (50m).
+	match := idx.SnapToHighway(24.005, 121.01, 0)
+
+	if match.OnFreeway {
+		t.Fatalf("expected OnFreeway=false for a fix 1km off the centerline")
+	}
+}
+
+func TestSnapToHighway_HeadingPicksOppositeDirection(t *testing.T) {
+	idx := NewIndex([]Segment{straightSegment()}) // tangent heading is due north (0 degrees)
+
+	// Heading due south (180) disagrees with the segment's northbound
+	// tangent by more than 90 degrees, so the opposite direction applies.
+	match := idx.SnapToHighway(24.005, 121.00005, 180)
+
+	if !match.OnFreeway {
+		t.Fatalf("expected OnFreeway=true")
+	}
+	if match.Direction != "南下" {
+		t.Errorf("Direction = %q, want 南下 when heading opposes the segment's tangent", match.Direction)
+	}
+}
+
+func TestDistanceToSegmentMeters_Endpoints(t *testing.T) {
+	seg := straightSegment()
+
+	atStart := distanceToSegmentMeters(seg.Start, seg)
+	if atStart > 1.0 {
+		t.Errorf("distance at segment start = %v meters, want ~0", atStart)
+	}
+
+	// ~1.1km north of the segment's end, so the closest point clamps to End.
+	beyondEnd := distanceToSegmentMeters(Point{Lat: 24.02, Lon: 121.0}, seg)
+	want := (0.02 - 0.01) * 111320.0
+	if diff := beyondEnd - want; diff > 1.0 || diff < -1.0 {
+		t.Errorf("distance beyond segment end = %v, want ~%v", beyondEnd, want)
+	}
+}
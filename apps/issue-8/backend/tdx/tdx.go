@@ -0,0 +1,179 @@
+// Package tdx implements a client for Taiwan's TDX (Transport Data eXchange)
+// platform. It handles OAuth2 client-credentials authentication and fetches
+// freeway service area metadata and real-time parking availability.
+package tdx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTokenURL   = "https://tdx.transportdata.tw/auth/realms/TDXConnect/protocol/openid-connect/token"
+	defaultBaseURL    = "https://tdx.transportdata.tw/api/basic"
+	serviceAreaPath   = "/v2/Road/Freeway/ServiceArea"
+	parkingAvailPath  = "/v2/Road/Freeway/ParkingAvailability"
+	tokenRefreshGuard = 60 * time.Second // refresh this long before expiry
+)
+
+// Config holds the credentials and endpoints needed to talk to TDX.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	TokenURL     string // defaults to defaultTokenURL when empty
+	BaseURL      string // defaults to defaultBaseURL when empty
+}
+
+// Client is an authenticated TDX API client. It is safe for concurrent use.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+// NewClient creates a TDX client from the given config. Missing URLs fall
+// back to the public TDX endpoints.
+func NewClient(cfg Config) *Client {
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = defaultTokenURL
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultBaseURL
+	}
+	return &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+}
+
+// token returns a valid access token, refreshing it if it is missing or
+// close to expiry.
+func (c *Client) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && time.Now().Before(c.expiresAt.Add(-tokenRefreshGuard)) {
+		return c.accessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	form.Set("client_id", c.cfg.ClientID)
+	form.Set("client_secret", c.cfg.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.TokenURL, bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("tdx: build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("tdx: request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("tdx: token endpoint returned %d: %s", resp.StatusCode, body)
+	}
+
+	var tok tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return "", fmt.Errorf("tdx: decode token response: %w", err)
+	}
+
+	c.accessToken = tok.AccessToken
+	c.expiresAt = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+
+	return c.accessToken, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	tok, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	reqURL := c.cfg.BaseURL + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("tdx: build request for %s: %w", path, err)
+	}
+	req.Header.Set("Authorization", "Bearer "+tok)
+	q := req.URL.Query()
+	q.Set("$format", "JSON")
+	req.URL.RawQuery = q.Encode()
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("tdx: request %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("tdx: %s returned %d: %s", path, resp.StatusCode, body)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("tdx: decode %s response: %w", path, err)
+	}
+
+	return nil
+}
+
+// ServiceAreaInfo is the static metadata TDX publishes for a freeway service
+// area (mirrors the shape of the FreewayServiceAreaInfo dataset).
+type ServiceAreaInfo struct {
+	ID          string  `json:"ServiceAreaID"`
+	Name        string  `json:"ServiceAreaName"`
+	Direction   string  `json:"Direction"` // N / S
+	Highway     string  `json:"FreewayID"`
+	Mileage     float64 `json:"Mileage"`
+	PositionLat float64 `json:"PositionLat"`
+	PositionLon float64 `json:"PositionLon"`
+}
+
+// FetchServiceAreas retrieves the freeway service area static metadata.
+func (c *Client) FetchServiceAreas(ctx context.Context) ([]ServiceAreaInfo, error) {
+	var areas []ServiceAreaInfo
+	if err := c.get(ctx, serviceAreaPath, &areas); err != nil {
+		return nil, err
+	}
+	return areas, nil
+}
+
+// ParkingAvailability is the real-time parking dataset TDX publishes per
+// service area.
+type ParkingAvailability struct {
+	ServiceAreaID   string    `json:"ServiceAreaID"`
+	AvailableSpaces int       `json:"AvailableSpaces"`
+	TotalSpaces     int       `json:"TotalSpaces"`
+	UpdateTime      time.Time `json:"SrcUpdateTime"`
+}
+
+// FetchParkingAvailability retrieves the real-time parking dataset.
+func (c *Client) FetchParkingAvailability(ctx context.Context) ([]ParkingAvailability, error) {
+	var avail []ParkingAvailability
+	if err := c.get(ctx, parkingAvailPath, &avail); err != nil {
+		return nil, err
+	}
+	return avail, nil
+}